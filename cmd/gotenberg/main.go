@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -29,7 +30,7 @@ func main() {
 	systemLogger.DebugOpf(op, "configuration: %+v", config)
 	if !config.DisableGoogleChrome() {
 		// start Google Chrome headless.
-		if err := chrome.Start(systemLogger, config.GoogleChromeIgnoreCertificateErrors()); err != nil {
+		if err := chrome.Start(systemLogger, chrome.DefaultOptions(config)); err != nil {
 			systemLogger.FatalOp(op, err)
 		}
 	}
@@ -51,7 +52,7 @@ func main() {
 	// block until we receive our signal.
 	<-quit
 	// create a deadline to wait for.
-	ctx, cancel := xcontext.WithTimeout(systemLogger, 120)
+	ctx, cancel := xcontext.WithTimeout(context.Background(), systemLogger, 120)
 	defer cancel()
 	// doesn't block if no connections, but will otherwise wait
 	// until the timeout deadline.
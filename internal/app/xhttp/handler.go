@@ -2,16 +2,24 @@ package xhttp
 
 import (
 	"bytes"
+	stdcontext "context"
 	"encoding/json"
 	"fmt"
+	"mime"
+	"net"
+	"net/http"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/labstack/echo/v4"
 	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/context"
+	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/job"
 	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/resource"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/chrome"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/egress"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/printer"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
@@ -19,6 +27,20 @@ import (
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xtime"
 )
 
+const (
+	/*
+		warningsHeaderKey is the header of the result
+		listing the warnings of the conversion as JSON.
+	*/
+	warningsHeaderKey string = "Gotenberg-Warnings"
+	/*
+		consoleHeaderKey is the header of the result
+		listing the JavaScript exceptions and console
+		messages of the conversion as JSON.
+	*/
+	consoleHeaderKey string = "Gotenberg-Console"
+)
+
 func pingEndpoint(config conf.Config) string {
 	return fmt.Sprintf("%s%s", config.RootPath(), "ping")
 }
@@ -27,6 +49,10 @@ func mergeEndpoint(config conf.Config) string {
 	return fmt.Sprintf("%s%s", config.RootPath(), "merge")
 }
 
+func splitEndpoint(config conf.Config) string {
+	return fmt.Sprintf("%s%s", config.RootPath(), "pdf/split")
+}
+
 func htmlEndpoint(config conf.Config) string {
 	return fmt.Sprintf("%s%s", config.RootPath(), "convert/html")
 }
@@ -43,15 +69,37 @@ func officeEndpoint(config conf.Config) string {
 	return fmt.Sprintf("%s%s", config.RootPath(), "convert/office")
 }
 
+func screenshotHTMLEndpoint(config conf.Config) string {
+	return fmt.Sprintf("%s%s", config.RootPath(), "screenshot/html")
+}
+
+func screenshotURLEndpoint(config conf.Config) string {
+	return fmt.Sprintf("%s%s", config.RootPath(), "screenshot/url")
+}
+
+func jobEndpoint(config conf.Config) string {
+	return fmt.Sprintf("%s%s", config.RootPath(), "jobs/:id")
+}
+
+func jobResultEndpoint(config conf.Config) string {
+	return fmt.Sprintf("%s%s", config.RootPath(), "jobs/:id/result")
+}
+
 func isMultipartFormDataEndpoint(config conf.Config, path string) bool {
 	var multipartFormDataEndpoints []string
-	multipartFormDataEndpoints = append(multipartFormDataEndpoints, mergeEndpoint(config))
+	multipartFormDataEndpoints = append(
+		multipartFormDataEndpoints,
+		mergeEndpoint(config),
+		splitEndpoint(config),
+	)
 	if !config.DisableGoogleChrome() {
 		multipartFormDataEndpoints = append(
 			multipartFormDataEndpoints,
 			htmlEndpoint(config),
 			urlEndpoint(config),
 			markdownEndpoint(config),
+			screenshotHTMLEndpoint(config),
+			screenshotURLEndpoint(config),
 		)
 	}
 	if !config.DisableUnoconv() {
@@ -68,6 +116,27 @@ func isMultipartFormDataEndpoint(config conf.Config, path string) bool {
 	return false
 }
 
+/*
+isPostProcessingEndpoint returns true if the
+resulting PDF file of the given endpoint may
+be post-processed (watermark, stamp, etc.).
+*/
+func isPostProcessingEndpoint(config conf.Config, path string) bool {
+	postProcessingEndpoints := []string{
+		mergeEndpoint(config),
+		htmlEndpoint(config),
+		urlEndpoint(config),
+		markdownEndpoint(config),
+		officeEndpoint(config),
+	}
+	for _, endpoint := range postProcessingEndpoints {
+		if endpoint == path {
+			return true
+		}
+	}
+	return false
+}
+
 // pingHandler is the handler for healthcheck.
 func pingHandler(c echo.Context) error {
 	const op string = "xhttp.pingHandler"
@@ -87,6 +156,46 @@ func pingHandler(c echo.Context) error {
 	return nil
 }
 
+// jobHandler is the handler for retrieving
+// the status of a job.
+func jobHandler(c echo.Context) error {
+	const op string = "xhttp.jobHandler"
+	resolver := func() error {
+		ctx := context.MustCastFromEchoContext(c)
+		logger := ctx.XLogger()
+		logger.DebugOp(op, "handling job request...")
+		j, err := ctx.Jobs().Get(ctx.Param("id"))
+		if err != nil {
+			return err
+		}
+		return ctx.JSON(http.StatusOK, j)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+// jobResultHandler is the handler for retrieving
+// the result file of a job.
+func jobResultHandler(c echo.Context) error {
+	const op string = "xhttp.jobResultHandler"
+	resolver := func() error {
+		ctx := context.MustCastFromEchoContext(c)
+		logger := ctx.XLogger()
+		logger.DebugOp(op, "handling job result request...")
+		fpath, filename, err := ctx.Jobs().Result(ctx.Param("id"))
+		if err != nil {
+			return err
+		}
+		return ctx.Attachment(fpath, filename)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
 // mergeHandler is the handler for merging
 // PDF files.
 func mergeHandler(c echo.Context) error {
@@ -100,12 +209,51 @@ func mergeHandler(c echo.Context) error {
 		if err != nil {
 			return xerror.New(op, err)
 		}
+		opts.Admission = ctx.Admissions().Processes
 		fpaths, err := r.Fpaths(".pdf")
 		if err != nil {
 			return err
 		}
+		opts.Bookmarks, err = resource.BookmarkTitlesArg(r, ctx.Config(), fpaths)
+		if err != nil {
+			return err
+		}
 		p := printer.NewMergePrinter(logger, fpaths, opts)
-		return convert(ctx, p)
+		return convert(ctx, p, ".pdf")
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+// splitHandler is the handler for splitting
+// a PDF file.
+func splitHandler(c echo.Context) error {
+	const op string = "xhttp.splitHandler"
+	resolver := func() error {
+		ctx := context.MustCastFromEchoContext(c)
+		logger := ctx.XLogger()
+		logger.DebugOp(op, "handling split request...")
+		r := ctx.MustResource()
+		opts, err := splitPrinterOptions(r, ctx.Config())
+		if err != nil {
+			return err
+		}
+		opts.Admission = ctx.Admissions().Processes
+		fpaths, err := r.Fpaths(".pdf")
+		if err != nil {
+			return err
+		}
+		if len(fpaths) != 1 {
+			return xerror.Invalid(
+				op,
+				fmt.Sprintf("expected one PDF file, got %d", len(fpaths)),
+				nil,
+			)
+		}
+		p := printer.NewSplitPrinter(logger, fpaths[0], opts)
+		return convert(ctx, p, printer.SplitExt(opts))
 	}
 	if err := resolver(); err != nil {
 		return xerror.New(op, err)
@@ -126,12 +274,13 @@ func htmlHandler(c echo.Context) error {
 		if err != nil {
 			return err
 		}
+		opts.Admission = ctx.Admissions().GoogleChrome
 		fpath, err := r.Fpath("index.html")
 		if err != nil {
 			return err
 		}
 		p := printer.NewHTMLPrinter(logger, fpath, opts)
-		return convert(ctx, p)
+		return convert(ctx, p, ".pdf")
 	}
 	if err := resolver(); err != nil {
 		return xerror.New(op, err)
@@ -152,7 +301,12 @@ func urlHandler(c echo.Context) error {
 		if err != nil {
 			return err
 		}
+		opts.Admission = ctx.Admissions().GoogleChrome
 		opts.CustomHTTPHeaders = resource.RemoteURLCustomHTTPHeaders(r)
+		opts.Cookies, err = resource.CookiesArg(r)
+		if err != nil {
+			return err
+		}
 		if !r.HasArg(resource.RemoteURLArgKey) {
 			return xerror.Invalid(
 				op,
@@ -164,8 +318,11 @@ func urlHandler(c echo.Context) error {
 		if err != nil {
 			return err
 		}
+		if err := opts.EgressPolicy.CheckURL(ctx.Request().Context(), remoteURL); err != nil {
+			return err
+		}
 		p := printer.NewURLPrinter(logger, remoteURL, opts)
-		return convert(ctx, p)
+		return convert(ctx, p, ".pdf")
 	}
 	if err := resolver(); err != nil {
 		return xerror.New(op, err)
@@ -186,6 +343,7 @@ func markdownHandler(c echo.Context) error {
 		if err != nil {
 			return err
 		}
+		opts.Admission = ctx.Admissions().GoogleChrome
 		fpath, err := r.Fpath("index.html")
 		if err != nil {
 			return err
@@ -194,7 +352,84 @@ func markdownHandler(c echo.Context) error {
 		if err != nil {
 			return err
 		}
-		return convert(ctx, p)
+		return convert(ctx, p, ".pdf")
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+// screenshotHTMLHandler is the handler for
+// taking a screenshot of an HTML file.
+func screenshotHTMLHandler(c echo.Context) error {
+	const op string = "xhttp.screenshotHTMLHandler"
+	resolver := func() error {
+		ctx := context.MustCastFromEchoContext(c)
+		logger := ctx.XLogger()
+		logger.DebugOp(op, "handling HTML screenshot request...")
+		r := ctx.MustResource()
+		opts, err := chromePrinterOptions(r, ctx.Config())
+		if err != nil {
+			return err
+		}
+		opts.Admission = ctx.Admissions().GoogleChrome
+		screenshotOpts, err := screenshotOptions(r, ctx.Config())
+		if err != nil {
+			return err
+		}
+		fpath, err := r.Fpath("index.html")
+		if err != nil {
+			return err
+		}
+		p := printer.NewHTMLScreenshotPrinter(logger, fpath, opts, screenshotOpts)
+		return convert(ctx, p, fmt.Sprintf(".%s", screenshotOpts.Format))
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+// screenshotURLHandler is the handler for
+// taking a screenshot of a URL.
+func screenshotURLHandler(c echo.Context) error {
+	const op string = "xhttp.screenshotURLHandler"
+	resolver := func() error {
+		ctx := context.MustCastFromEchoContext(c)
+		logger := ctx.XLogger()
+		logger.DebugOp(op, "handling URL screenshot request...")
+		r := ctx.MustResource()
+		opts, err := chromePrinterOptions(r, ctx.Config())
+		if err != nil {
+			return err
+		}
+		opts.Admission = ctx.Admissions().GoogleChrome
+		opts.CustomHTTPHeaders = resource.RemoteURLCustomHTTPHeaders(r)
+		opts.Cookies, err = resource.CookiesArg(r)
+		if err != nil {
+			return err
+		}
+		screenshotOpts, err := screenshotOptions(r, ctx.Config())
+		if err != nil {
+			return err
+		}
+		if !r.HasArg(resource.RemoteURLArgKey) {
+			return xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' not found or empty", resource.RemoteURLArgKey),
+				nil,
+			)
+		}
+		remoteURL, err := r.StringArg(resource.RemoteURLArgKey, "")
+		if err != nil {
+			return err
+		}
+		if err := opts.EgressPolicy.CheckURL(ctx.Request().Context(), remoteURL); err != nil {
+			return err
+		}
+		p := printer.NewURLScreenshotPrinter(logger, remoteURL, opts, screenshotOpts)
+		return convert(ctx, p, fmt.Sprintf(".%s", screenshotOpts.Format))
 	}
 	if err := resolver(); err != nil {
 		return xerror.New(op, err)
@@ -215,6 +450,7 @@ func officeHandler(c echo.Context) error {
 		if err != nil {
 			return err
 		}
+		opts.Admission = ctx.Admissions().Processes
 		fpaths, err := r.Fpaths(
 			".txt",
 			".rtf",
@@ -232,8 +468,19 @@ func officeHandler(c echo.Context) error {
 		if err != nil {
 			return err
 		}
+		if opts.PDFFormat != "" && len(fpaths) > 1 {
+			return xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' cannot be satisfied when merging several files", opts.PDFFormat),
+				nil,
+			)
+		}
+		opts.Bookmarks, err = resource.BookmarkTitlesArg(r, ctx.Config(), fpaths)
+		if err != nil {
+			return err
+		}
 		p := printer.NewOfficePrinter(logger, fpaths, opts)
-		return convert(ctx, p)
+		return convert(ctx, p, ".pdf")
 	}
 	if err := resolver(); err != nil {
 		return xerror.New(op, err)
@@ -241,26 +488,55 @@ func officeHandler(c echo.Context) error {
 	return nil
 }
 
-func convert(ctx context.Context, p printer.Printer) error {
+func convert(ctx context.Context, p printer.Printer, ext string) error {
 	const op string = "xhttp.convert"
 	resolver := func() error {
 		logger := ctx.XLogger()
 		r := ctx.MustResource()
 		baseFilename := xrand.Get()
-		filename := fmt.Sprintf("%s.pdf", baseFilename)
+		filename := fmt.Sprintf("%s%s", baseFilename, ext)
 		fpath := fmt.Sprintf("%s/%s", r.DirPath(), filename)
-		// if no webhook URL given, run conversion
-		// and directly return the resulting PDF file
-		// or an error.
-		if !r.HasArg(resource.WebhookURLArgKey) {
-			logger.DebugOpf(op, "no '%s' found, converting synchronously", resource.WebhookURLArgKey)
-			return convertSync(ctx, p, filename, fpath)
+		async, err := r.BoolArg(resource.AsyncArgKey, false)
+		if err != nil {
+			return err
+		}
+		// the webhook URLs are checked before
+		// the conversion too, even if the egress
+		// policy applies again when sending.
+		if err := checkWebhookURLs(ctx); err != nil {
+			return err
+		}
+		// validate the post-processing options
+		// before the conversion.
+		postProcessOpts := printer.DefaultPostProcessOptions(ctx.Config())
+		if isPostProcessingEndpoint(ctx.Config(), ctx.Path()) {
+			postProcessOpts, err = postProcessOptions(r, ctx.Config(), ctx.Path() == officeEndpoint(ctx.Config()))
+			if err != nil {
+				return err
+			}
 		}
-		// as a webhook URL has been given, we
-		// run the following lines in a goroutine so that
-		// it doesn't block.
-		logger.DebugOpf(op, "'%s' found, converting asynchronously", resource.WebhookURLArgKey)
-		return convertAsync(ctx, p, filename, fpath)
+		// if no webhook URL given and if not a job,
+		// run conversion and directly return the
+		// resulting PDF file or an error.
+		if !r.HasArg(resource.WebhookURLArgKey) && !async {
+			logger.DebugOpf(
+				op,
+				"no '%s' nor '%s' found, converting synchronously",
+				resource.WebhookURLArgKey,
+				resource.AsyncArgKey,
+			)
+			return convertSync(ctx, p, postProcessOpts, filename, fpath)
+		}
+		// as a webhook URL has been given or as
+		// it is a job, we run the following lines
+		// in a goroutine so that it doesn't block.
+		logger.DebugOpf(
+			op,
+			"'%s' or '%s' found, converting asynchronously",
+			resource.WebhookURLArgKey,
+			resource.AsyncArgKey,
+		)
+		return convertAsync(ctx, p, postProcessOpts, filename, fpath)
 	}
 	if err := resolver(); err != nil {
 		return xerror.New(op, err)
@@ -268,13 +544,25 @@ func convert(ctx context.Context, p printer.Printer) error {
 	return nil
 }
 
-func convertSync(ctx context.Context, p printer.Printer, filename, fpath string) error {
+func convertSync(ctx context.Context, p printer.Printer, postProcessOpts printer.PostProcessOptions, filename, fpath string) error {
 	const op = "xhttp.convertSync"
 	resolver := func() error {
 		logger := ctx.XLogger()
 		r := ctx.MustResource()
 
-		if err := p.Print(fpath); err != nil {
+		// the conversion stops as soon as
+		// the client disconnects.
+		printErr := p.Print(ctx.Request().Context(), fpath)
+		// the report helps debugging
+		// a failed conversion too.
+		if err := setReportHeaders(ctx.Response().Header(), p); err != nil {
+			return err
+		}
+		if printErr != nil {
+			return printErr
+		}
+		postProcessOpts = withDocumentMetadata(postProcessOpts, p)
+		if err := printer.PostProcess(ctx.Request().Context(), logger, fpath, postProcessOpts); err != nil {
 			return err
 		}
 		if !r.HasArg(resource.ResultFilenameArgKey) {
@@ -309,7 +597,7 @@ func convertSync(ctx context.Context, p printer.Printer, filename, fpath string)
 	return nil
 }
 
-func convertAsync(ctx context.Context, p printer.Printer, filename, fpath string) error {
+func convertAsync(ctx context.Context, p printer.Printer, postProcessOpts printer.PostProcessOptions, filename, fpath string) error {
 	const op = "xhttp.convertAsync"
 	logger := ctx.XLogger()
 	r := ctx.MustResource()
@@ -325,27 +613,72 @@ func convertAsync(ctx context.Context, p printer.Printer, filename, fpath string
 	if err != nil {
 		return xerror.New(op, err)
 	}
+	async, err := r.BoolArg(resource.AsyncArgKey, false)
+	if err != nil {
+		return xerror.New(op, err)
+	}
+	resultFilename, err := r.StringArg(resource.ResultFilenameArgKey, filename)
+	if err != nil {
+		return xerror.New(op, err)
+	}
+	var j job.Job
+	if async {
+		j, err = ctx.Jobs().Create()
+		if err != nil {
+			return xerror.New(op, err)
+		}
+	}
 	go func() {
 		defer r.Close()
-		if err := p.Print(fpath); err != nil {
+		fail := func(err error) {
 			xerr := xerror.New(op, err)
 			logger.ErrorOp(xerror.Op(xerr), xerr)
-			sendToErrorWebhook(ctx, xerr)
+			if async {
+				if err := ctx.Jobs().Fail(j.ID, xerr); err != nil {
+					logger.ErrorOp(op, err)
+				}
+			}
+			sendToErrorWebhook(ctx, p, xerr)
+		}
+		if async {
+			if err := ctx.Jobs().Run(j.ID); err != nil {
+				fail(err)
+				return
+			}
+		}
+		// the request context.Context is done as soon
+		// as the handler returns: the conversion
+		// only stops on timeout.
+		if err := p.Print(stdcontext.Background(), fpath); err != nil {
+			fail(err)
+			return
+		}
+		postProcessOpts = withDocumentMetadata(postProcessOpts, p)
+		if err := printer.PostProcess(stdcontext.Background(), logger, fpath, postProcessOpts); err != nil {
+			fail(err)
 			return
 		}
-		f, err := os.Open(fpath)
+		resultFpath := fpath
+		if async {
+			dest, err := ctx.Jobs().Succeed(j.ID, fpath, resultFilename)
+			if err != nil {
+				fail(err)
+				return
+			}
+			resultFpath = dest
+		}
+		if !r.HasArg(resource.WebhookURLArgKey) {
+			return
+		}
+		f, err := os.Open(resultFpath)
 		if err != nil {
-			xerr := xerror.New(op, err)
-			logger.ErrorOp(xerror.Op(xerr), xerr)
-			sendToErrorWebhook(ctx, xerr)
+			fail(err)
 			return
 		}
 		defer f.Close()
 		stat, err := f.Stat()
 		if err != nil {
-			xerr := xerror.New(op, err)
-			logger.ErrorOp(xerror.Op(xerr), xerr)
-			sendToErrorWebhook(ctx, xerr)
+			fail(err)
 			return
 		}
 		logger.DebugOpf(
@@ -354,9 +687,7 @@ func convertAsync(ctx context.Context, p printer.Printer, filename, fpath string
 			filename,
 			webhookURL,
 		)
-		httpClient := retryablehttp.NewClient()
-		httpClient.Logger = retryablehttp.LeveledLogger(xlog.NewLeveledLogger(logger, op))
-		httpClient.HTTPClient.Timeout = xtime.Duration(webhookURLTimeout)
+		httpClient := webhookClient(ctx, op, webhookURLTimeout)
 		req, err := retryablehttp.NewRequest(webhookURLMethod, webhookURL, f)
 		if err != nil {
 			xerr := xerror.New(op, err)
@@ -364,8 +695,13 @@ func convertAsync(ctx context.Context, p printer.Printer, filename, fpath string
 			return
 		}
 		req.Header.Set("X-Trace-Id", logger.GetTraceId())
-		req.Header.Set(echo.HeaderContentType, "application/pdf")
+		req.Header.Set(echo.HeaderContentType, contentType(filename))
 		req.ContentLength = stat.Size()
+		if err := setReportHeaders(req.Header, p); err != nil {
+			xerr := xerror.New(op, err)
+			logger.ErrorOp(xerror.Op(xerr), xerr)
+			return
+		}
 		// set custom headers (if any).
 		customHTTPHeaders := resource.WebhookURLCustomHTTPHeaders(r)
 		if len(customHTTPHeaders) > 0 {
@@ -398,10 +734,137 @@ func convertAsync(ctx context.Context, p printer.Printer, filename, fpath string
 			resp.Status,
 		)
 	}()
+	if !async {
+		return nil
+	}
+	// the client may poll the job status
+	// thanks to its ID.
+	if err := ctx.JSON(http.StatusAccepted, j); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+setReportHeaders sets the warnings and the
+console entries of the given printer.Printer
+as JSON headers, if there are any.
+*/
+func setReportHeaders(header http.Header, p printer.Printer) error {
+	const op string = "xhttp.setReportHeaders"
+	reporter, ok := p.(printer.Reporter)
+	if !ok {
+		return nil
+	}
+	set := func(key string, value interface{}, length int) error {
+		if length == 0 {
+			return nil
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		header.Set(key, string(b))
+		return nil
+	}
+	warnings := reporter.Warnings()
+	if err := set(warningsHeaderKey, warnings, len(warnings)); err != nil {
+		return xerror.New(op, err)
+	}
+	consoleEntries := reporter.ConsoleEntries()
+	if err := set(consoleHeaderKey, consoleEntries, len(consoleEntries)); err != nil {
+		return xerror.New(op, err)
+	}
 	return nil
 }
 
-func sendToErrorWebhook(ctx context.Context, xerr error) {
+/*
+withDocumentMetadata returns the given
+printer.PostProcessOptions with the metadata
+read by the printer.Printer in place of the
+ones not set by the form fields.
+*/
+func withDocumentMetadata(opts printer.PostProcessOptions, p printer.Printer) printer.PostProcessOptions {
+	reader, ok := p.(printer.MetadataReader)
+	if !ok {
+		return opts
+	}
+	opts.Metadata = opts.Metadata.WithDefaults(reader.DocumentMetadata())
+	return opts
+}
+
+/*
+contentType returns the Content-Type of
+a result file according to its extension.
+*/
+func contentType(filename string) string {
+	ext := filepath.Ext(filename)
+	// the ZIP archives of the split endpoint
+	// are not always in the system MIME types.
+	if ext == ".zip" {
+		return "application/zip"
+	}
+	return mime.TypeByExtension(ext)
+}
+
+/*
+checkWebhookURLs returns an error with
+xerror.ForbiddenCode if the egress policy
+denies the webhook URL or the webhook error
+URL of the request.
+*/
+func checkWebhookURLs(ctx context.Context) error {
+	const op string = "xhttp.checkWebhookURLs"
+	resolver := func() error {
+		r := ctx.MustResource()
+		policy := egress.New(ctx.Config())
+		for _, key := range []resource.ArgKey{
+			resource.WebhookURLArgKey,
+			resource.WebhookErrorURLArgKey,
+		} {
+			if !r.HasArg(key) {
+				continue
+			}
+			webhookURL, err := r.StringArg(key, "")
+			if err != nil {
+				return err
+			}
+			if err := policy.CheckURL(ctx.Request().Context(), webhookURL); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+webhookClient returns the HTTP client for
+sending a result or an error to a webhook.
+
+Its transport checks the address of each
+connection against the egress policy, so
+that a redirect or a DNS record changing
+after checkWebhookURLs does not reach a
+denied address.
+*/
+func webhookClient(ctx context.Context, op string, timeout float64) *retryablehttp.Client {
+	httpClient := retryablehttp.NewClient()
+	httpClient.Logger = retryablehttp.LeveledLogger(xlog.NewLeveledLogger(ctx.XLogger(), op))
+	httpClient.HTTPClient.Timeout = xtime.Duration(timeout)
+	if transport, ok := httpClient.HTTPClient.Transport.(*http.Transport); ok {
+		transport.DialContext = egress.New(ctx.Config()).DialContext(&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		})
+	}
+	return httpClient
+}
+
+func sendToErrorWebhook(ctx context.Context, p printer.Printer, xerr error) {
 	const op = "xhttp.sendToErrorWebhook"
 	logger := ctx.XLogger()
 	r := ctx.MustResource()
@@ -439,9 +902,12 @@ func sendToErrorWebhook(ctx context.Context, xerr error) {
 		}
 		req.Header.Set("X-Trace-Id", logger.GetTraceId())
 		req.Header.Set(echo.HeaderContentType, "application/json")
-		httpClient := retryablehttp.NewClient()
-		httpClient.Logger = retryablehttp.LeveledLogger(xlog.NewLeveledLogger(logger, op))
-		httpClient.HTTPClient.Timeout = xtime.Duration(webhookURLTimeout)
+		if err := setReportHeaders(req.Header, p); err != nil {
+			xerr := xerror.New(op, err)
+			logger.ErrorOp(xerror.Op(xerr), xerr)
+			return
+		}
+		httpClient := webhookClient(ctx, op, webhookURLTimeout)
 		resp, err := httpClient.Do(req) /* #nosec */
 		if err != nil {
 			xerr := xerror.New(op, err)
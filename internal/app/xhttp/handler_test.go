@@ -1,15 +1,20 @@
 package xhttp
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/job"
 	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/resource"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
 	"github.com/thecodingmachine/gotenberg/test"
@@ -68,6 +73,75 @@ func TestMergeHandler(t *testing.T) {
 	test.AssertStatusCode(t, http.StatusGatewayTimeout, srv, req)
 }
 
+func TestSplitHandler(t *testing.T) {
+	config := conf.DefaultConfig()
+	srv := New(config)
+	endpoint := splitEndpoint(config)
+	// should return 200 with a PDF file.
+	body, contentType := test.SplitMultipartForm(t, map[string]string{string(resource.PageRangesArgKey): "1"})
+	req := httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/pdf", rec.Header().Get(echo.HeaderContentType))
+	// should return 200 with a ZIP archive.
+	body, contentType = test.SplitMultipartForm(t, map[string]string{string(resource.SplitModeArgKey): "pages"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/zip", rec.Header().Get(echo.HeaderContentType))
+	// should return 200 as the unknown
+	// form fields are skipped.
+	large := strings.Repeat("a", 17<<20)
+	body, contentType = test.SplitMultipartForm(t, map[string]string{
+		string(resource.PageRangesArgKey): "1",
+		"foo":                             large,
+		"bar":                             large,
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusOK, srv, req)
+	// should return 400 as the form fields
+	// are too large altogether.
+	body, contentType = test.SplitMultipartForm(t, map[string]string{
+		string(resource.PageRangesArgKey): "1",
+		string(resource.TitleArgKey):      large,
+		string(resource.AuthorArgKey):     large,
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "pageRanges"
+	// form field is missing.
+	body, contentType = test.SplitMultipartForm(t, nil)
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "pageRanges"
+	// form field value is invalid.
+	body, contentType = test.SplitMultipartForm(t, map[string]string{string(resource.PageRangesArgKey): "3-1"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as there is
+	// more than one PDF file.
+	body, contentType = test.MergeMultipartForm(t, map[string]string{string(resource.PageRangesArgKey): "1"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 504.
+	body, contentType = test.SplitMultipartForm(t, map[string]string{
+		string(resource.PageRangesArgKey):  "1",
+		string(resource.WaitTimeoutArgKey): "0",
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusGatewayTimeout, srv, req)
+}
+
 func TestHTMLHandler(t *testing.T) {
 	config := conf.DefaultConfig()
 	srv := New(config)
@@ -84,6 +158,153 @@ func TestHTMLHandler(t *testing.T) {
 	body, _ = test.HTMLMultipartForm(t, nil)
 	req = httptest.NewRequest(http.MethodPost, endpoint, body)
 	test.AssertStatusCode(t, http.StatusUnsupportedMediaType, srv, req)
+	// should return 200 with a watermark
+	// and a stamp.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{
+		string(resource.WatermarkTextArgKey): "DRAFT",
+		string(resource.StampTextArgKey):     "CONFIDENTIAL",
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusOK, srv, req)
+	// should return 400 as "watermarkOpacity"
+	// form field value is > 1.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{
+		string(resource.WatermarkTextArgKey):    "DRAFT",
+		string(resource.WatermarkOpacityArgKey): "2",
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 200 with an encrypted PDF.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{
+		string(resource.UserPasswordArgKey):  "foo",
+		string(resource.OwnerPasswordArgKey): "bar",
+		string(resource.AllowCopyingArgKey):  "false",
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusOK, srv, req)
+	// should return 400 as "allowPrinting"
+	// form field requires an owner password.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.AllowPrintingArgKey): "false"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "pdfFormat"
+	// form field requires unoconv.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.PDFFormatArgKey): "PDF/A-2b"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "pdfFormat" form
+	// field is only available for the Office
+	// endpoint.
+	t.Setenv(conf.DisableUnoconvEnvVar, "0")
+	unoconvConfig, err := conf.FromEnv()
+	require.Nil(t, err)
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.PDFFormatArgKey): "PDF/A-2b"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, New(unoconvConfig), req)
+	// should return 400 as "bookmarks"
+	// form field is not a boolean.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.BookmarksArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "taggedPDF"
+	// form field is not a boolean.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.TaggedPDFArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "accessibilityCheck"
+	// form field is not a boolean.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.AccessibilityCheckArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "resourceFailurePolicy"
+	// form field value is not a policy.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.ResourceFailurePolicyArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "ignoredStatusCodes"
+	// form field value is not a JSON array.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.IgnoredStatusCodesArgKey): "404"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "waitForSelectorVisible"
+	// form field requires "waitForSelector".
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.WaitForSelectorVisibleArgKey): "true"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "emulatedMediaType"
+	// form field value is not a media type.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.EmulatedMediaTypeArgKey): "tv"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "printBackground"
+	// form field is not a boolean.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.PrintBackgroundArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "preferCSSPageSize"
+	// form field is not a boolean.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.PreferCSSPageSizeArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "blockedResourceTypes"
+	// form field value has a wrong resource type.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.BlockedResourceTypesArgKey): `["video"]`})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "timezone"
+	// form field value is not an IANA
+	// time zone.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.TimezoneArgKey): "Mars/Olympus"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "locale"
+	// form field value is not a BCP 47
+	// language tag.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.LocaleArgKey): "fr_FR.UTF-8"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "deviceScaleFactor"
+	// form field value is < 0.1.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.DeviceScaleFactorArgKey): "0"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "javascriptErrorPolicy"
+	// form field value is not a policy.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.JavaScriptErrorPolicyArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "metadataFromHTML"
+	// form field is not a boolean.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.MetadataFromHTMLArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "attachments"
+	// form field lists a missing file.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.AttachmentsArgKey): `["factur-x.xml"]`})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
 	// should return 400 as "waitTimeout" form field
 	// value is < 0.
 	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.WaitTimeoutArgKey): "-1"})
@@ -221,6 +442,21 @@ func TestHTMLHandler(t *testing.T) {
 	req = httptest.NewRequest(http.MethodPost, endpoint, body)
 	req.Header.Set(echo.HeaderContentType, contentType)
 	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 403 as "webhookURL" form field
+	// value targets the loopback address.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.WebhookURLArgKey): "http://127.0.0.1:3001/foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusForbidden, srv, req)
+	// should return 403 as "webhookErrorURL" form
+	// field value targets the loopback address.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{
+		string(resource.WebhookURLArgKey):      "https://93.184.216.34/foo",
+		string(resource.WebhookErrorURLArgKey): "http://localhost:3001/error",
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusForbidden, srv, req)
 }
 
 func TestURLHandler(t *testing.T) {
@@ -262,6 +498,12 @@ func TestURLHandler(t *testing.T) {
 	req = httptest.NewRequest(http.MethodPost, endpoint, body)
 	req.Header.Set(echo.HeaderContentType, contentType)
 	test.AssertStatusCode(t, http.StatusGatewayTimeout, srv, req)
+	// should return 400 as "cookies" form field
+	// value is not a JSON array of cookies.
+	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.CookiesArgKey): "session=foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
 	// should return 400 as "waitDelay" form field
 	// value is < 0.
 	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.WaitDelayArgKey): "-1"})
@@ -376,6 +618,18 @@ func TestURLHandler(t *testing.T) {
 	req = httptest.NewRequest(http.MethodPost, endpoint, body)
 	req.Header.Set(echo.HeaderContentType, contentType)
 	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 403 as "remoteURL" form field
+	// value targets a cloud metadata endpoint.
+	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.RemoteURLArgKey): "http://169.254.169.254/latest/meta-data/"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusForbidden, srv, req)
+	// should return 403 as "remoteURL" form field
+	// value is not an HTTP(S) URL.
+	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.RemoteURLArgKey): "file:///etc/passwd"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusForbidden, srv, req)
 }
 
 func TestMarkdownHandler(t *testing.T) {
@@ -533,6 +787,58 @@ func TestMarkdownHandler(t *testing.T) {
 	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
 }
 
+func TestScreenshotHandlers(t *testing.T) {
+	config := conf.DefaultConfig()
+	srv := New(config)
+	// should return 200.
+	body, contentType := test.HTMLMultipartForm(t, nil)
+	req := httptest.NewRequest(http.MethodPost, screenshotHTMLEndpoint(config), body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusOK, srv, req)
+	// should return 200.
+	body, contentType = test.URLMultipartForm(t, map[string]string{
+		string(resource.FormatArgKey):   "jpeg",
+		string(resource.QualityArgKey):  "80",
+		string(resource.FullPageArgKey): "true",
+	})
+	req = httptest.NewRequest(http.MethodPost, screenshotURLEndpoint(config), body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusOK, srv, req)
+	// should return 405 as Method is wrong.
+	req = httptest.NewRequest(http.MethodGet, screenshotHTMLEndpoint(config), nil)
+	test.AssertStatusCode(t, http.StatusMethodNotAllowed, srv, req)
+	// should return 400 as "format" form field
+	// value is not a screenshot format.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.FormatArgKey): "gif"})
+	req = httptest.NewRequest(http.MethodPost, screenshotHTMLEndpoint(config), body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "quality" form field
+	// value is > 100.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.QualityArgKey): "101"})
+	req = httptest.NewRequest(http.MethodPost, screenshotHTMLEndpoint(config), body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "fullPage" form field
+	// value is invalid.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.FullPageArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, screenshotHTMLEndpoint(config), body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "viewportWidth" form field
+	// value is < 1.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.ViewportWidthArgKey): "0"})
+	req = httptest.NewRequest(http.MethodPost, screenshotHTMLEndpoint(config), body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "clipSelector" form field
+	// value does not match any element.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.ClipSelectorArgKey): "#foo"})
+	req = httptest.NewRequest(http.MethodPost, screenshotHTMLEndpoint(config), body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+}
+
 func TestOfficeHandler(t *testing.T) {
 	config := conf.DefaultConfig()
 	srv := New(config)
@@ -579,6 +885,21 @@ func TestOfficeHandler(t *testing.T) {
 	req = httptest.NewRequest(http.MethodPost, endpoint, body)
 	req.Header.Set(echo.HeaderContentType, contentType)
 	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as merging PDF/A
+	// files does not produce a PDF/A file.
+	body, contentType = test.OfficeMultipartForm(t, map[string]string{string(resource.PDFFormatArgKey): "PDF/A-1b"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as a watermark would
+	// modify the PDF/A file.
+	body, contentType = test.OfficeMultipartForm(t, map[string]string{
+		string(resource.PDFFormatArgKey):     "PDF/A-1b",
+		string(resource.WatermarkTextArgKey): "DRAFT",
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
 }
 
 func TestWebhook(t *testing.T) {
@@ -611,7 +932,11 @@ func TestWebhook(t *testing.T) {
 	go func() {
 		rcv.Start(":3001")
 	}()
-	config := conf.DefaultConfig()
+	// the egress policy denies
+	// the loopback address otherwise.
+	t.Setenv(conf.EgressAllowedHostsEnvVar, "localhost")
+	config, err := conf.FromEnv()
+	assert.Nil(t, err)
 	srv := New(config)
 	// our custom server should receive the PDF.
 	body, contentType := test.MergeMultipartForm(t, map[string]string{string(resource.WebhookURLArgKey): "http://localhost:3001/foo"})
@@ -619,7 +944,7 @@ func TestWebhook(t *testing.T) {
 	req.Header.Set(echo.HeaderContentType, contentType)
 	req.Header.Set(customHeaderKey, customHeaderValue)
 	test.AssertStatusCode(t, http.StatusOK, srv, req)
-	err := <-status
+	err = <-status
 	assert.NoError(t, err)
 }
 
@@ -633,3 +958,49 @@ func TestResultFilename(t *testing.T) {
 	srv.ServeHTTP(rec, req)
 	assert.Equal(t, "attachment; filename=\"foo.pdf\"", rec.Header().Get(echo.HeaderContentDisposition))
 }
+
+func TestJobHandlers(t *testing.T) {
+	config := conf.DefaultConfig()
+	srv := New(config)
+	jobPath := func(id string) string {
+		return strings.Replace(jobEndpoint(config), ":id", id, 1)
+	}
+	jobResultPath := func(id string) string {
+		return strings.Replace(jobResultEndpoint(config), ":id", id, 1)
+	}
+	// should return 404 as the job does not exist.
+	req := httptest.NewRequest(http.MethodGet, jobPath("foo"), nil)
+	test.AssertStatusCode(t, http.StatusNotFound, srv, req)
+	req = httptest.NewRequest(http.MethodGet, jobResultPath("foo"), nil)
+	test.AssertStatusCode(t, http.StatusNotFound, srv, req)
+	// should return 202 alongside the job.
+	body, contentType := test.HTMLMultipartForm(t, map[string]string{string(resource.AsyncArgKey): "true"})
+	req = httptest.NewRequest(http.MethodPost, htmlEndpoint(config), body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	var j job.Job
+	err := json.Unmarshal(rec.Body.Bytes(), &j)
+	require.Nil(t, err)
+	assert.NotEmpty(t, j.ID)
+	// the job should eventually succeed.
+	for i := 0; i < 100 && (j.Status == job.QueuedStatus || j.Status == job.RunningStatus); i++ {
+		time.Sleep(100 * time.Millisecond)
+		req = httptest.NewRequest(http.MethodGet, jobPath(j.ID), nil)
+		rec = httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		err = json.Unmarshal(rec.Body.Bytes(), &j)
+		require.Nil(t, err)
+	}
+	assert.Equal(t, job.SucceededStatus, j.Status)
+	req = httptest.NewRequest(http.MethodGet, jobResultPath(j.ID), nil)
+	test.AssertStatusCode(t, http.StatusOK, srv, req)
+	// should return 400 as "async" form field
+	// value is invalid.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.AsyncArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, htmlEndpoint(config), body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+}
@@ -1,11 +1,14 @@
 package xhttp
 
 import (
+	"fmt"
+	"math"
 	"net/http"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/context"
+	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/job"
 	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/resource"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
@@ -15,7 +18,7 @@ import (
 
 // contextMiddleware extends the default echo.Context with
 // our custom context.Context.
-func contextMiddleware(config conf.Config) echo.MiddlewareFunc {
+func contextMiddleware(config conf.Config, jobs *job.Store, admissions context.Admissions) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			// generate a unique identifier for the request.
@@ -32,7 +35,7 @@ func contextMiddleware(config conf.Config) echo.MiddlewareFunc {
 			logger := xlog.New(config.LogLevel(), trace)
 			// extend the current echo context with our custom
 			// context.
-			ctx := context.New(c, logger, config)
+			ctx := context.New(c, logger, config, jobs, admissions)
 			// if it's not a multipart/form-data request,
 			// there is no need to create a Resource.
 			if !isMultipartFormDataEndpoint(config, ctx.Path()) {
@@ -131,11 +134,12 @@ func doCleanup(ctx context.Context, err error) error {
 		return err
 	}
 	r := ctx.MustResource()
-	// if a webhook URL has been given,
-	// do not remove the resource.Resource here because
-	// we don't know if the result file has been
-	// generated or sent.
-	if r.HasArg(resource.WebhookURLArgKey) {
+	// if a webhook URL has been given or if the
+	// conversion is a job, do not remove the
+	// resource.Resource here because we don't know
+	// if the result file has been generated or sent.
+	async, _ := r.BoolArg(resource.AsyncArgKey, false)
+	if r.HasArg(resource.WebhookURLArgKey) || async {
 		return err
 	}
 	// a resource.Resource is associated with our custom context.
@@ -168,6 +172,16 @@ func doErr(ctx context.Context, err error) error {
 		httpErr = echo.NewHTTPError(http.StatusBadRequest, errMessage)
 	case xerror.TimeoutCode:
 		httpErr = echo.NewHTTPError(http.StatusGatewayTimeout, errMessage)
+	case xerror.NotFoundCode:
+		httpErr = echo.NewHTTPError(http.StatusNotFound, errMessage)
+	case xerror.ForbiddenCode:
+		httpErr = echo.NewHTTPError(http.StatusForbidden, errMessage)
+	case xerror.BusyCode:
+		ctx.Response().Header().Set("Retry-After", retryAfter(ctx.Config()))
+		httpErr = echo.NewHTTPError(http.StatusTooManyRequests, errMessage)
+	case xerror.UnavailableCode:
+		ctx.Response().Header().Set("Retry-After", retryAfter(ctx.Config()))
+		httpErr = echo.NewHTTPError(http.StatusServiceUnavailable, errMessage)
 	default:
 		httpErr = echo.NewHTTPError(http.StatusInternalServerError, errMessage)
 	}
@@ -175,3 +189,13 @@ func doErr(ctx context.Context, err error) error {
 	ctx.Error(httpErr)
 	return httpErr
 }
+
+/*
+retryAfter returns the value of the "Retry-After"
+header, in seconds, for conversions which have not
+been admitted.
+*/
+func retryAfter(config conf.Config) string {
+	const minimum float64 = 1.0
+	return fmt.Sprintf("%.0f", math.Max(math.Ceil(config.QueueWaitTimeout()), minimum))
+}
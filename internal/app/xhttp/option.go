@@ -1,10 +1,14 @@
 package xhttp
 
 import (
+	"fmt"
+
 	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/resource"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/egress"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/printer"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xpdf"
 )
 
 func mergePrinterOptions(r resource.Resource, config conf.Config) (printer.MergePrinterOptions, error) {
@@ -15,9 +19,123 @@ func mergePrinterOptions(r resource.Resource, config conf.Config) (printer.Merge
 	}
 	return printer.MergePrinterOptions{
 		WaitTimeout: waitTimeout,
+		Backend:     config.MergeBackend(),
 	}, nil
 }
 
+func splitPrinterOptions(r resource.Resource, config conf.Config) (printer.SplitPrinterOptions, error) {
+	const op string = "xhttp.splitPrinterOptions"
+	resolver := func() (printer.SplitPrinterOptions, error) {
+		waitTimeout, err := resource.WaitTimeoutArg(r, config)
+		if err != nil {
+			return printer.SplitPrinterOptions{}, err
+		}
+		mode, pageRanges, err := resource.SplitArgs(r, config)
+		if err != nil {
+			return printer.SplitPrinterOptions{}, err
+		}
+		return printer.SplitPrinterOptions{
+			WaitTimeout: waitTimeout,
+			Mode:        mode,
+			PageRanges:  pageRanges,
+		}, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+postProcessOptions returns the post-processing
+options of the resource.
+
+Only LibreOffice exports PDF/A files, i.e. when
+office is true: converting a PDF file afterwards
+would import its pages as drawings, which loses
+its text, links and tags.
+*/
+func postProcessOptions(r resource.Resource, config conf.Config, office bool) (printer.PostProcessOptions, error) {
+	const op string = "xhttp.postProcessOptions"
+	resolver := func() (printer.PostProcessOptions, error) {
+		waitTimeout, err := resource.WaitTimeoutArg(r, config)
+		if err != nil {
+			return printer.PostProcessOptions{}, err
+		}
+		overlays, err := resource.OverlayArgs(r, config)
+		if err != nil {
+			return printer.PostProcessOptions{}, err
+		}
+		pdfFormat, err := resource.PDFFormatArg(r, config)
+		if err != nil {
+			return printer.PostProcessOptions{}, err
+		}
+		if pdfFormat != "" && !office {
+			return printer.PostProcessOptions{}, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' can only be satisfied by the Office endpoint", pdfFormat),
+				nil,
+			)
+		}
+		// the overlays would modify
+		// the exported PDF/A file.
+		if pdfFormat != "" && len(overlays) > 0 {
+			return printer.PostProcessOptions{}, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' cannot be satisfied with a watermark or a stamp", pdfFormat),
+				nil,
+			)
+		}
+		metadata, err := resource.MetadataArgs(r, config)
+		if err != nil {
+			return printer.PostProcessOptions{}, err
+		}
+		attachments, err := resource.AttachmentArgs(r, config)
+		if err != nil {
+			return printer.PostProcessOptions{}, err
+		}
+		// PDF/A-1 forbids embedded files, and
+		// PDF/A-2 only allows PDF/A files.
+		if len(attachments) > 0 && (pdfFormat == xpdf.PDFA1b || pdfFormat == xpdf.PDFA2b) {
+			return printer.PostProcessOptions{}, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' cannot be satisfied with attachments: use '%s'", pdfFormat, xpdf.PDFA3b),
+				nil,
+			)
+		}
+		encryption, encrypt, err := resource.EncryptionArgs(r, config)
+		if err != nil {
+			return printer.PostProcessOptions{}, err
+		}
+		if pdfFormat != "" && encrypt {
+			return printer.PostProcessOptions{}, xerror.Invalid(
+				op,
+				fmt.Sprintf(
+					"'%s' cannot be satisfied as PDF/A forbids encryption: remove '%s' and '%s'",
+					pdfFormat,
+					resource.UserPasswordArgKey,
+					resource.OwnerPasswordArgKey,
+				),
+				nil,
+			)
+		}
+		return printer.PostProcessOptions{
+			WaitTimeout: waitTimeout,
+			Overlays:    overlays,
+			Metadata:    metadata,
+			Attachments: attachments,
+			Encrypt:     encrypt,
+			Encryption:  encryption,
+		}, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
 func chromePrinterOptions(r resource.Resource, config conf.Config) (printer.ChromePrinterOptions, error) {
 	const op string = "xhttp.chromePrinterOptions"
 	resolver := func() (printer.ChromePrinterOptions, error) {
@@ -33,6 +151,15 @@ func chromePrinterOptions(r resource.Resource, config conf.Config) (printer.Chro
 		if err != nil {
 			return printer.ChromePrinterOptions{}, err
 		}
+		waitForSelector, waitForSelectorVisible,
+			err := resource.WaitForSelectorArgs(r, config)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		waitForExpression, err := resource.WaitForExpressionArg(r, config)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
 		headerHTML, footerHTML,
 			err := resource.HeaderFooterContents(r, config)
 		if err != nil {
@@ -64,30 +191,138 @@ func chromePrinterOptions(r resource.Resource, config conf.Config) (printer.Chro
 		if err != nil {
 			return printer.ChromePrinterOptions{}, err
 		}
-		maxConnections := config.GoogleChromeMaxConnections()
 		waitForConnection, err := r.BoolArg(resource.WaitForConnectionArgKey, config.GoogleChromeWaitForConnection())
 		if err != nil {
 			return printer.ChromePrinterOptions{}, err
 		}
+		outline, err := resource.BookmarksArg(r, config)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		taggedPDF, err := r.BoolArg(resource.TaggedPDFArgKey, false)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		accessibilityCheck, err := r.BoolArg(resource.AccessibilityCheckArgKey, false)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		metadataFromHTML, err := r.BoolArg(resource.MetadataFromHTMLArgKey, false)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		resourceFailurePolicy, err := resource.ResourceFailurePolicyArg(r, config)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		ignoredStatusCodes, err := resource.IgnoredStatusCodesArg(r, config)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		ignoredResourceURLs, fatalResourceURLs,
+			err := resource.ResourceURLPatternsArgs(r, config)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		javaScriptErrorPolicy, err := resource.JavaScriptErrorPolicyArg(r, config)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		emulatedMediaType, preferredColorScheme,
+			err := resource.MediaArgs(r, config)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		printBackground, err := r.BoolArg(resource.PrintBackgroundArgKey, true)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		omitBackground, err := r.BoolArg(resource.OmitBackgroundArgKey, false)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		preferCSSPageSize, err := r.BoolArg(resource.PreferCSSPageSizeArgKey, false)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		displayHeaderFooter, err := r.BoolArg(resource.DisplayHeaderFooterArgKey, true)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		viewportWidth, viewportHeight,
+			err := resource.ViewportArgs(r, config)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		deviceScaleFactor, err := resource.DeviceScaleFactorArg(r, config)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		mobile, err := r.BoolArg(resource.MobileArgKey, false)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		timezone, err := resource.TimezoneArg(r)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		locale, err := resource.LocaleArg(r)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		userAgent, err := r.StringArg(resource.UserAgentArgKey, "")
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		requestFilters, err := resource.RequestFiltersArgs(r, config)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
 		return printer.ChromePrinterOptions{
-			WaitTimeout:        waitTimeout,
-			WaitDelay:          waitDelay,
-			WaitJSRenderStatus: waitJSRenderStatus,
-			HeaderHTML:         headerHTML,
-			FooterHTML:         footerHTML,
-			PaperWidth:         paperWidth,
-			PaperHeight:        paperHeight,
-			MarginTop:          marginTop,
-			MarginBottom:       marginBottom,
-			MarginLeft:         marginLeft,
-			MarginRight:        marginRight,
-			Landscape:          landscape,
-			PageRanges:         pageRanges,
-			RpccBufferSize:     googleChromeRpccBufferSize,
-			CustomHTTPHeaders:  make(map[string]string),
-			Scale:              scale,
-			MaxConnections:     maxConnections,
-			WaitForConnection:  waitForConnection,
+			WaitTimeout:            waitTimeout,
+			WaitDelay:              waitDelay,
+			WaitJSRenderStatus:     waitJSRenderStatus,
+			WaitForSelector:        waitForSelector,
+			WaitForSelectorVisible: waitForSelectorVisible,
+			WaitForExpression:      waitForExpression,
+			HeaderHTML:             headerHTML,
+			FooterHTML:             footerHTML,
+			PaperWidth:             paperWidth,
+			PaperHeight:            paperHeight,
+			MarginTop:              marginTop,
+			MarginBottom:           marginBottom,
+			MarginLeft:             marginLeft,
+			MarginRight:            marginRight,
+			Landscape:              landscape,
+			PageRanges:             pageRanges,
+			RpccBufferSize:         googleChromeRpccBufferSize,
+			CustomHTTPHeaders:      make(map[string]string),
+			Scale:                  scale,
+			WaitForConnection:      waitForConnection,
+			Outline:                outline,
+			TaggedPDF:              taggedPDF,
+			AccessibilityCheck:     accessibilityCheck,
+			MetadataFromHTML:       metadataFromHTML,
+			ResourceFailurePolicy:  resourceFailurePolicy,
+			IgnoredStatusCodes:     ignoredStatusCodes,
+			IgnoredResourceURLs:    ignoredResourceURLs,
+			FatalResourceURLs:      fatalResourceURLs,
+			JavaScriptErrorPolicy:  javaScriptErrorPolicy,
+			EmulatedMediaType:      emulatedMediaType,
+			PreferredColorScheme:   preferredColorScheme,
+			PrintBackground:        printBackground,
+			OmitBackground:         omitBackground,
+			PreferCSSPageSize:      preferCSSPageSize,
+			DisplayHeaderFooter:    displayHeaderFooter,
+			ViewportWidth:          viewportWidth,
+			ViewportHeight:         viewportHeight,
+			DeviceScaleFactor:      deviceScaleFactor,
+			Mobile:                 mobile,
+			Timezone:               timezone,
+			Locale:                 locale,
+			UserAgent:              userAgent,
+			RequestFilters:         requestFilters,
+			EgressPolicy:           egress.New(config),
 		}, nil
 	}
 	opts, err := resolver()
@@ -112,10 +347,50 @@ func officePrinterOptions(r resource.Resource, config conf.Config) (printer.Offi
 		if err != nil {
 			return printer.OfficePrinterOptions{}, err
 		}
+		pdfFormat, err := resource.PDFFormatArg(r, config)
+		if err != nil {
+			return printer.OfficePrinterOptions{}, err
+		}
 		return printer.OfficePrinterOptions{
-			WaitTimeout: waitTimeout,
-			Landscape:   landscape,
-			PageRanges:  pageRanges,
+			WaitTimeout:  waitTimeout,
+			Landscape:    landscape,
+			PageRanges:   pageRanges,
+			PDFFormat:    pdfFormat,
+			MergeBackend: config.MergeBackend(),
+		}, nil
+	}
+	opts, err := resolver()
+	if err != nil {
+		return opts, xerror.New(op, err)
+	}
+	return opts, nil
+}
+
+func screenshotOptions(r resource.Resource, config conf.Config) (printer.ScreenshotOptions, error) {
+	const op string = "xhttp.screenshotOptions"
+	resolver := func() (printer.ScreenshotOptions, error) {
+		format, err := resource.ScreenshotFormatArg(r, config)
+		if err != nil {
+			return printer.ScreenshotOptions{}, err
+		}
+		quality, err := resource.QualityArg(r, config)
+		if err != nil {
+			return printer.ScreenshotOptions{}, err
+		}
+		fullPage, err := r.BoolArg(resource.FullPageArgKey, false)
+		if err != nil {
+			return printer.ScreenshotOptions{}, err
+		}
+		clipSelector, err := r.StringArg(resource.ClipSelectorArgKey, "")
+		if err != nil {
+			return printer.ScreenshotOptions{}, err
+		}
+		return printer.ScreenshotOptions{
+			Format:       format,
+			Quality:      quality,
+			FullPage:     fullPage,
+			ClipSelector: clipSelector,
+			MaxHeight:    config.GoogleChromeMaxScreenshotHeight(),
 		}, nil
 	}
 	opts, err := resolver()
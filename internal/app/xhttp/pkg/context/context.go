@@ -3,6 +3,7 @@ package context
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"path/filepath"
 	"reflect"
@@ -11,7 +12,9 @@ import (
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/job"
 	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/resource"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/admission"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
@@ -20,18 +23,33 @@ import (
 // Context extends the default echo.Context.
 type Context struct {
 	echo.Context
-	logger    xlog.Logger
-	config    conf.Config
-	resource  resource.Resource
-	startTime time.Time
+	logger     xlog.Logger
+	config     conf.Config
+	jobs       *job.Store
+	admissions Admissions
+	resource   resource.Resource
+	startTime  time.Time
+}
+
+/*
+Admissions gathers the admission controllers
+shared by all the requests.
+*/
+type Admissions struct {
+	// GoogleChrome limits the Google Chrome conversions.
+	GoogleChrome *admission.Controller
+	// Processes limits the unoconv and PDFtk processes.
+	Processes *admission.Controller
 }
 
 // New creates a new Context.
-func New(c echo.Context, logger xlog.Logger, config conf.Config) Context {
+func New(c echo.Context, logger xlog.Logger, config conf.Config, jobs *job.Store, admissions Admissions) Context {
 	return Context{
 		c,
 		logger,
 		config,
+		jobs,
+		admissions,
 		resource.Resource{},
 		time.Now(),
 	}
@@ -70,10 +88,36 @@ func (ctx Context) Config() conf.Config {
 	return ctx.config
 }
 
-// WithResource creates a resource.Resource and
-// adds it to the Context.
+// Jobs returns the job.Store associated
+// with the Context.
+func (ctx Context) Jobs() *job.Store {
+	return ctx.jobs
+}
+
+// Admissions returns the admission controllers
+// associated with the Context.
+func (ctx Context) Admissions() Admissions {
+	return ctx.admissions
+}
+
+/*
+WithResource creates a resource.Resource and
+adds it to the Context.
+
+The multipart/form-data body is read part by
+part so that the resource.Resource keeps the
+files in the order they have been sent.
+
+Only the form fields of the arguments are
+kept, up to maxMemory bytes in total.
+*/
 func (ctx *Context) WithResource(directoryName string) error {
-	const op string = "context.Context.WithResource"
+	const (
+		op string = "context.Context.WithResource"
+		// same as the default maximum memory
+		// of http.Request.ParseMultipartForm.
+		maxMemory int64 = 32 << 20
+	)
 	resolver := func() (resource.Resource, error) {
 		r, err := resource.New(ctx.logger, directoryName)
 		if err != nil {
@@ -83,36 +127,75 @@ func (ctx *Context) WithResource(directoryName string) error {
 		for key, value := range ctx.Request().Header {
 			r.WithCustomHTTPHeader(key, value[0])
 		}
-		// retrieve form values from request.
-		for _, key := range resource.ArgKeys() {
-			r.WithArg(key, ctx.FormValue(string(key)))
-		}
-		// write form files from request.
-		form, err := ctx.MultipartForm()
+		reader, err := ctx.Request().MultipartReader()
 		if err != nil {
-			/*
-				(very) special case: one and
-				only one file has been sent
-				and it is empty.
-			*/
-			if strings.Contains(err.Error(), io.EOF.Error()) {
-				return r, xerror.Invalid(op, "one file has been sent but it is empty: does it exist?", err)
-			}
 			return r, err
 		}
-		for _, files := range form.File {
-			for _, fh := range files {
-				in, err := fh.Open()
+		// retrieve form values and write form
+		// files from request.
+		argKeys := make(map[string]bool)
+		for _, key := range resource.ArgKeys() {
+			argKeys[string(key)] = true
+		}
+		values := make(map[string]string)
+		remaining := maxMemory
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				/*
+					(very) special case: one and
+					only one file has been sent
+					and it is empty.
+				*/
+				if strings.Contains(err.Error(), io.EOF.Error()) {
+					return r, xerror.Invalid(op, "one file has been sent but it is empty: does it exist?", err)
+				}
+				return r, err
+			}
+			if part.FileName() == "" {
+				name := part.FormName()
+				// as http.Request.FormValue, only
+				// keep the first value.
+				if _, ok := values[name]; ok || !argKeys[name] {
+					ctx.logger.DebugOpf(op, "skipping form field '%s'...", name)
+					part.Close() // nolint: errcheck
+					continue
+				}
+				b, err := ioutil.ReadAll(io.LimitReader(part, remaining+1))
+				part.Close() // nolint: errcheck
 				if err != nil {
 					return r, err
 				}
-				defer in.Close()
-				// avoid directory traversal.
-				filename := filepath.Base(fh.Filename)
-				if err := r.WithFile(filename, in); err != nil {
-					return r, err
+				remaining -= int64(len(b))
+				if remaining < 0 {
+					return r, xerror.Invalid(
+						op,
+						fmt.Sprintf("form fields are too large: they should not exceed %d bytes", maxMemory),
+						nil,
+					)
 				}
+				values[name] = string(b)
+				continue
+			}
+			// avoid directory traversal.
+			filename := filepath.Base(part.FileName())
+			err = r.WithFile(filename, part)
+			part.Close() // nolint: errcheck
+			if err != nil {
+				return r, err
+			}
+		}
+		for _, key := range resource.ArgKeys() {
+			value, ok := values[string(key)]
+			if !ok {
+				// as http.Request.FormValue, fallback
+				// to the URL query parameters.
+				value = ctx.QueryParam(string(key))
 			}
+			r.WithArg(key, value)
 		}
 		return r, nil
 	}
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/job"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/admission"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
 	"github.com/thecodingmachine/gotenberg/test"
 )
@@ -15,6 +17,8 @@ func TestMustCastFromEchoContext(t *testing.T) {
 		test.DummyEchoContext(),
 		test.DebugLogger(),
 		conf.DefaultConfig(),
+		job.NewStore(test.DebugLogger(), 60.0, 60.0, 10),
+		Admissions{},
 	)
 	assert.NotPanics(t, func() {
 		result := MustCastFromEchoContext(ctx)
@@ -31,6 +35,8 @@ func TestLogRequestResult(t *testing.T) {
 		test.DummyEchoContext(),
 		test.DebugLogger(),
 		conf.DefaultConfig(),
+		job.NewStore(test.DebugLogger(), 60.0, 60.0, 10),
+		Admissions{},
 	)
 	// Info log.
 	err := ctx.LogRequestResult(nil, false)
@@ -47,15 +53,26 @@ func TestGetters(t *testing.T) {
 	const resourceDirectoryName string = "foo"
 	logger := test.DebugLogger()
 	config := conf.DefaultConfig()
+	jobs := job.NewStore(logger, 60.0, 60.0, 10)
+	admissions := Admissions{
+		GoogleChrome: admission.New(1, 1, 0),
+		Processes:    admission.New(1, 1, 0),
+	}
 	ctx := New(
 		test.DummyEchoContext(),
 		logger,
 		config,
+		jobs,
+		admissions,
 	)
 	// Logger.
 	assert.Equal(t, logger, ctx.XLogger())
 	// Config.
 	assert.Equal(t, config, ctx.Config())
+	// Jobs.
+	assert.Equal(t, jobs, ctx.Jobs())
+	// Admissions.
+	assert.Equal(t, admissions, ctx.Admissions())
 	// Context should not have a resource.Resource.
 	assert.Equal(t, false, ctx.HasResource())
 	assert.Panics(t, func() {
@@ -66,6 +83,8 @@ func TestGetters(t *testing.T) {
 		test.EchoContextMultipart(t),
 		logger,
 		config,
+		jobs,
+		admissions,
 	)
 	err := ctx.WithResource(resourceDirectoryName)
 	assert.Nil(t, err)
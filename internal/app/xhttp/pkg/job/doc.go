@@ -0,0 +1,8 @@
+/*
+Package job helps tracking asynchronous
+conversions and storing their results.
+
+All functions return our standard xerror.Error
+in case of error.
+*/
+package job
@@ -0,0 +1,290 @@
+package job
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/resource"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xrand"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xtime"
+)
+
+/*
+ResultDirectory is the directory
+where all the results of the jobs
+are located, alongside the resources
+directories.
+*/
+const ResultDirectory string = resource.TemporaryDirectory + "/jobs"
+
+// Status is a type for
+// jobs' statuses.
+type Status string
+
+const (
+	// QueuedStatus is the status of a job
+	// waiting to be processed.
+	QueuedStatus Status = "queued"
+	// RunningStatus is the status of a job
+	// being processed.
+	RunningStatus Status = "running"
+	// SucceededStatus is the status of a job
+	// which has a result.
+	SucceededStatus Status = "succeeded"
+	// FailedStatus is the status of a job
+	// which has failed.
+	FailedStatus Status = "failed"
+)
+
+// Job represents an asynchronous conversion.
+type Job struct {
+	ID        string `json:"id"`
+	Status    Status `json:"status"`
+	Code      string `json:"code,omitempty"`
+	Message   string `json:"message,omitempty"`
+	filename  string
+	fpath     string
+	expiresAt time.Time
+}
+
+// Store keeps track of the jobs and
+// of their results.
+type Store struct {
+	logger     xlog.Logger
+	dirPath    string
+	ttl        time.Duration
+	timeout    time.Duration
+	maxPending int
+	mu         *sync.RWMutex
+	jobs       map[string]*Job
+}
+
+/*
+NewStore creates a Store where the results
+are kept during given TTL in seconds once the
+corresponding jobs are done.
+
+A job which is not done after given timeout
+in seconds is considered lost and expires
+like a done one. The Store refuses new jobs
+while there are already given maximum number
+of queued or running jobs.
+*/
+func NewStore(logger xlog.Logger, ttl, timeout float64, maxPending int64) *Store {
+	s := &Store{
+		logger:     logger,
+		dirPath:    ResultDirectory,
+		ttl:        xtime.Duration(ttl),
+		timeout:    xtime.Duration(timeout),
+		maxPending: int(maxPending),
+		mu:         &sync.RWMutex{},
+		jobs:       make(map[string]*Job),
+	}
+	go s.janitor()
+	return s
+}
+
+/*
+Create adds a new queued job to the Store.
+
+It returns an error with xerror.BusyCode
+if there are too many pending jobs.
+*/
+func (s *Store) Create() (Job, error) {
+	const op string = "job.Store.Create"
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pending := 0
+	for _, j := range s.jobs {
+		if !j.done() && !j.expired(now) {
+			pending++
+		}
+	}
+	if pending >= s.maxPending {
+		return Job{}, xerror.Busy(
+			op,
+			fmt.Sprintf("too many pending jobs (%d): please retry later", pending),
+			nil,
+		)
+	}
+	// a job which is never done, e.g. because its
+	// result could not be stored, still expires.
+	j := &Job{
+		ID:        xrand.Get(),
+		Status:    QueuedStatus,
+		expiresAt: now.Add(s.timeout + s.ttl),
+	}
+	s.jobs[j.ID] = j
+	s.logger.DebugOpf(op, "job '%s' created", j.ID)
+	return *j, nil
+}
+
+// Run marks the job identified by given ID
+// as running.
+func (s *Store) Run(id string) error {
+	const op string = "job.Store.Run"
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return notFound(op, id)
+	}
+	j.Status = RunningStatus
+	s.logger.DebugOpf(op, "job '%s' running", id)
+	return nil
+}
+
+/*
+Succeed moves the given result file into
+the Store and marks the job identified by
+given ID as succeeded.
+
+It returns the new path of the result file.
+*/
+func (s *Store) Succeed(id, fpath, filename string) (string, error) {
+	const op string = "job.Store.Succeed"
+	resolver := func() (string, error) {
+		if err := os.MkdirAll(s.dirPath, 0755); err != nil {
+			return "", err
+		}
+		absDirPath, err := filepath.Abs(s.dirPath)
+		if err != nil {
+			return "", err
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		j, ok := s.jobs[id]
+		if !ok {
+			return "", notFound(op, id)
+		}
+		dest := fmt.Sprintf("%s/%s%s", absDirPath, id, filepath.Ext(fpath))
+		if err := os.Rename(fpath, dest); err != nil {
+			return "", err
+		}
+		j.Status = SucceededStatus
+		j.filename = filename
+		j.fpath = dest
+		j.expiresAt = time.Now().Add(s.ttl)
+		return dest, nil
+	}
+	dest, err := resolver()
+	if err != nil {
+		return "", xerror.New(op, err)
+	}
+	s.logger.DebugOpf(op, "job '%s' succeeded", id)
+	return dest, nil
+}
+
+// Fail marks the job identified by given ID
+// as failed because of given error.
+func (s *Store) Fail(id string, cause error) error {
+	const op string = "job.Store.Fail"
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return notFound(op, id)
+	}
+	j.Status = FailedStatus
+	j.Code = string(xerror.Code(cause))
+	j.Message = xerror.Message(cause)
+	j.expiresAt = time.Now().Add(s.ttl)
+	s.logger.DebugOpf(op, "job '%s' failed", id)
+	return nil
+}
+
+// Get returns the job identified by given ID.
+func (s *Store) Get(id string) (Job, error) {
+	const op string = "job.Store.Get"
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	j, ok := s.jobs[id]
+	if !ok || j.expired(time.Now()) {
+		return Job{}, notFound(op, id)
+	}
+	return *j, nil
+}
+
+/*
+Result returns the path and the filename
+of the result file of the job identified
+by given ID.
+
+The job should have succeeded.
+*/
+func (s *Store) Result(id string) (string, string, error) {
+	const op string = "job.Store.Result"
+	j, err := s.Get(id)
+	if err != nil {
+		return "", "", xerror.New(op, err)
+	}
+	if j.Status != SucceededStatus {
+		return "", "", xerror.Invalid(
+			op,
+			fmt.Sprintf("job '%s' has no result (status: %s)", id, j.Status),
+			nil,
+		)
+	}
+	return j.fpath, j.filename, nil
+}
+
+// janitor periodically removes the expired
+// jobs and their result files.
+func (s *Store) janitor() {
+	const sweepInterval time.Duration = time.Minute
+	interval := sweepInterval
+	if s.ttl > 0 && s.ttl < interval {
+		interval = s.ttl
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		s.sweep(now)
+	}
+}
+
+func (s *Store) sweep(now time.Time) {
+	const op string = "job.Store.sweep"
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, j := range s.jobs {
+		if !j.expired(now) {
+			continue
+		}
+		if j.fpath != "" {
+			if err := os.Remove(j.fpath); err != nil && !os.IsNotExist(err) {
+				// find a way to bubble up this error?
+				s.logger.ErrorOpf(op, "failed to remove result file '%s': %s", j.fpath, err.Error())
+			}
+		}
+		delete(s.jobs, id)
+		s.logger.DebugOpf(op, "job '%s' expired", id)
+	}
+}
+
+// expired returns true if the job has
+// reached its deadline or, once done, if
+// its time to live has elapsed.
+func (j Job) expired(now time.Time) bool {
+	return now.After(j.expiresAt)
+}
+
+// done returns true if the job
+// has succeeded or failed.
+func (j Job) done() bool {
+	return j.Status == SucceededStatus || j.Status == FailedStatus
+}
+
+func notFound(op, id string) error {
+	return xerror.NotFound(
+		op,
+		fmt.Sprintf("job '%s' does not exist", id),
+		nil,
+	)
+}
@@ -0,0 +1,121 @@
+package job
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestStore(t *testing.T) {
+	s := NewStore(test.DebugLogger(), 60.0, 60.0, 2)
+	// a new job should be queued.
+	j, err := s.Create()
+	assert.Nil(t, err)
+	assert.NotEmpty(t, j.ID)
+	assert.Equal(t, QueuedStatus, j.Status)
+	// should be running.
+	err = s.Run(j.ID)
+	assert.Nil(t, err)
+	j, err = s.Get(j.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, RunningStatus, j.Status)
+	// should not be OK as the job has
+	// no result yet.
+	_, _, err = s.Result(j.ID)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// should have succeeded.
+	f, err := ioutil.TempFile("", "*.pdf")
+	require.Nil(t, err)
+	require.Nil(t, f.Close())
+	dest, err := s.Succeed(j.ID, f.Name(), "foo.pdf")
+	assert.Nil(t, err)
+	assert.FileExists(t, dest)
+	fpath, filename, err := s.Result(j.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, dest, fpath)
+	assert.Equal(t, "foo.pdf", filename)
+	// should have failed.
+	j, err = s.Create()
+	require.Nil(t, err)
+	err = s.Fail(j.ID, xerror.Invalid("foo", "bar", nil))
+	assert.Nil(t, err)
+	j, err = s.Get(j.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, FailedStatus, j.Status)
+	assert.Equal(t, string(xerror.InvalidCode), j.Code)
+	assert.Equal(t, "bar", j.Message)
+	// should not be OK as the job
+	// does not exist.
+	_, err = s.Get("foo")
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.NotFoundCode, xerror.Code(err))
+	err = s.Run("foo")
+	test.AssertError(t, err)
+	err = s.Fail("foo", errors.New("foo"))
+	test.AssertError(t, err)
+	// finally...
+	err = os.RemoveAll(ResultDirectory)
+	assert.Nil(t, err)
+}
+
+func TestStoreMaxPending(t *testing.T) {
+	s := NewStore(test.DebugLogger(), 60.0, 60.0, 2)
+	first, err := s.Create()
+	require.Nil(t, err)
+	_, err = s.Create()
+	require.Nil(t, err)
+	// should not be OK as there are
+	// too many pending jobs.
+	_, err = s.Create()
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.BusyCode, xerror.Code(err))
+	// should be OK as a job is done.
+	err = s.Fail(first.ID, errors.New("foo"))
+	require.Nil(t, err)
+	_, err = s.Create()
+	assert.Nil(t, err)
+}
+
+func TestSweep(t *testing.T) {
+	s := NewStore(test.DebugLogger(), 60.0, 60.0, 2)
+	j, err := s.Create()
+	require.Nil(t, err)
+	f, err := ioutil.TempFile("", "*.pdf")
+	require.Nil(t, err)
+	require.Nil(t, f.Close())
+	dest, err := s.Succeed(j.ID, f.Name(), "foo.pdf")
+	require.Nil(t, err)
+	// should still be available.
+	s.sweep(time.Now())
+	_, err = s.Get(j.ID)
+	assert.Nil(t, err)
+	assert.FileExists(t, dest)
+	// should have been removed alongside
+	// its result file.
+	s.sweep(time.Now().Add(2 * time.Minute))
+	_, err = s.Get(j.ID)
+	test.AssertError(t, err)
+	_, err = os.Stat(dest)
+	assert.True(t, os.IsNotExist(err))
+	// a job which is not done should expire
+	// once its deadline has been reached.
+	j, err = s.Create()
+	require.Nil(t, err)
+	s.sweep(time.Now().Add(time.Minute))
+	_, err = s.Get(j.ID)
+	assert.Nil(t, err)
+	s.sweep(time.Now().Add(3 * time.Minute))
+	_, err = s.Get(j.ID)
+	test.AssertError(t, err)
+	// finally...
+	err = os.RemoveAll(ResultDirectory)
+	assert.Nil(t, err)
+}
@@ -1,10 +1,16 @@
 package resource
 
 import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/printer"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xassert"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xpdf"
 )
 
 // ArgKey is a type for
@@ -72,6 +78,177 @@ const (
 	// WaitForConnectionArgKey is the key
 	// of the argument "waitForConnection".
 	WaitForConnectionArgKey ArgKey = "waitForConnection"
+	// AsyncArgKey is the key
+	// of the argument "async".
+	AsyncArgKey ArgKey = "async"
+	// FormatArgKey is the key
+	// of the argument "format".
+	FormatArgKey ArgKey = "format"
+	// QualityArgKey is the key
+	// of the argument "quality".
+	QualityArgKey ArgKey = "quality"
+	// FullPageArgKey is the key
+	// of the argument "fullPage".
+	FullPageArgKey ArgKey = "fullPage"
+	// ClipSelectorArgKey is the key
+	// of the argument "clipSelector".
+	ClipSelectorArgKey ArgKey = "clipSelector"
+	// ViewportWidthArgKey is the key
+	// of the argument "viewportWidth".
+	ViewportWidthArgKey ArgKey = "viewportWidth"
+	// ViewportHeightArgKey is the key
+	// of the argument "viewportHeight".
+	ViewportHeightArgKey ArgKey = "viewportHeight"
+	// OrderArgKey is the key
+	// of the argument "order".
+	OrderArgKey ArgKey = "order"
+	// SplitModeArgKey is the key
+	// of the argument "splitMode".
+	SplitModeArgKey ArgKey = "splitMode"
+	// WatermarkTextArgKey is the key
+	// of the argument "watermarkText".
+	WatermarkTextArgKey ArgKey = "watermarkText"
+	// WatermarkOpacityArgKey is the key
+	// of the argument "watermarkOpacity".
+	WatermarkOpacityArgKey ArgKey = "watermarkOpacity"
+	// WatermarkRotationArgKey is the key
+	// of the argument "watermarkRotation".
+	WatermarkRotationArgKey ArgKey = "watermarkRotation"
+	// WatermarkPageRangesArgKey is the key
+	// of the argument "watermarkPageRanges".
+	WatermarkPageRangesArgKey ArgKey = "watermarkPageRanges"
+	// StampTextArgKey is the key
+	// of the argument "stampText".
+	StampTextArgKey ArgKey = "stampText"
+	// StampOpacityArgKey is the key
+	// of the argument "stampOpacity".
+	StampOpacityArgKey ArgKey = "stampOpacity"
+	// StampRotationArgKey is the key
+	// of the argument "stampRotation".
+	StampRotationArgKey ArgKey = "stampRotation"
+	// StampPageRangesArgKey is the key
+	// of the argument "stampPageRanges".
+	StampPageRangesArgKey ArgKey = "stampPageRanges"
+	// UserPasswordArgKey is the key
+	// of the argument "userPassword".
+	UserPasswordArgKey ArgKey = "userPassword"
+	// OwnerPasswordArgKey is the key
+	// of the argument "ownerPassword".
+	OwnerPasswordArgKey ArgKey = "ownerPassword"
+	// AllowPrintingArgKey is the key
+	// of the argument "allowPrinting".
+	AllowPrintingArgKey ArgKey = "allowPrinting"
+	// AllowCopyingArgKey is the key
+	// of the argument "allowCopying".
+	AllowCopyingArgKey ArgKey = "allowCopying"
+	// PDFFormatArgKey is the key
+	// of the argument "pdfFormat".
+	PDFFormatArgKey ArgKey = "pdfFormat"
+	// AttachmentsArgKey is the key
+	// of the argument "attachments".
+	AttachmentsArgKey ArgKey = "attachments"
+	// AttachmentsRelationshipArgKey is the key
+	// of the argument "attachmentsRelationship".
+	AttachmentsRelationshipArgKey ArgKey = "attachmentsRelationship"
+	// TitleArgKey is the key
+	// of the argument "title".
+	TitleArgKey ArgKey = "title"
+	// AuthorArgKey is the key
+	// of the argument "author".
+	AuthorArgKey ArgKey = "author"
+	// SubjectArgKey is the key
+	// of the argument "subject".
+	SubjectArgKey ArgKey = "subject"
+	// KeywordsArgKey is the key
+	// of the argument "keywords".
+	KeywordsArgKey ArgKey = "keywords"
+	// MetadataFromHTMLArgKey is the key
+	// of the argument "metadataFromHTML".
+	MetadataFromHTMLArgKey ArgKey = "metadataFromHTML"
+	// BookmarksArgKey is the key
+	// of the argument "bookmarks".
+	BookmarksArgKey ArgKey = "bookmarks"
+	// BookmarkLabelsArgKey is the key
+	// of the argument "bookmarkLabels".
+	BookmarkLabelsArgKey ArgKey = "bookmarkLabels"
+	// TaggedPDFArgKey is the key
+	// of the argument "taggedPDF".
+	TaggedPDFArgKey ArgKey = "taggedPDF"
+	// AccessibilityCheckArgKey is the key
+	// of the argument "accessibilityCheck".
+	AccessibilityCheckArgKey ArgKey = "accessibilityCheck"
+	// ResourceFailurePolicyArgKey is the key
+	// of the argument "resourceFailurePolicy".
+	ResourceFailurePolicyArgKey ArgKey = "resourceFailurePolicy"
+	// IgnoredStatusCodesArgKey is the key
+	// of the argument "ignoredStatusCodes".
+	IgnoredStatusCodesArgKey ArgKey = "ignoredStatusCodes"
+	// IgnoredResourceURLsArgKey is the key
+	// of the argument "ignoredResourceURLs".
+	IgnoredResourceURLsArgKey ArgKey = "ignoredResourceURLs"
+	// FatalResourceURLsArgKey is the key
+	// of the argument "fatalResourceURLs".
+	FatalResourceURLsArgKey ArgKey = "fatalResourceURLs"
+	// JavaScriptErrorPolicyArgKey is the key
+	// of the argument "javascriptErrorPolicy".
+	JavaScriptErrorPolicyArgKey ArgKey = "javascriptErrorPolicy"
+	// WaitForSelectorArgKey is the key
+	// of the argument "waitForSelector".
+	WaitForSelectorArgKey ArgKey = "waitForSelector"
+	// WaitForSelectorVisibleArgKey is the key
+	// of the argument "waitForSelectorVisible".
+	WaitForSelectorVisibleArgKey ArgKey = "waitForSelectorVisible"
+	// WaitForExpressionArgKey is the key
+	// of the argument "waitForExpression".
+	WaitForExpressionArgKey ArgKey = "waitForExpression"
+	// EmulatedMediaTypeArgKey is the key
+	// of the argument "emulatedMediaType".
+	EmulatedMediaTypeArgKey ArgKey = "emulatedMediaType"
+	// PreferredColorSchemeArgKey is the key
+	// of the argument "preferredColorScheme".
+	PreferredColorSchemeArgKey ArgKey = "preferredColorScheme"
+	// PrintBackgroundArgKey is the key
+	// of the argument "printBackground".
+	PrintBackgroundArgKey ArgKey = "printBackground"
+	// OmitBackgroundArgKey is the key
+	// of the argument "omitBackground".
+	OmitBackgroundArgKey ArgKey = "omitBackground"
+	// PreferCSSPageSizeArgKey is the key
+	// of the argument "preferCSSPageSize".
+	PreferCSSPageSizeArgKey ArgKey = "preferCSSPageSize"
+	// DisplayHeaderFooterArgKey is the key
+	// of the argument "displayHeaderFooter".
+	DisplayHeaderFooterArgKey ArgKey = "displayHeaderFooter"
+	// DeviceScaleFactorArgKey is the key
+	// of the argument "deviceScaleFactor".
+	DeviceScaleFactorArgKey ArgKey = "deviceScaleFactor"
+	// MobileArgKey is the key
+	// of the argument "mobile".
+	MobileArgKey ArgKey = "mobile"
+	// TimezoneArgKey is the key
+	// of the argument "timezone".
+	TimezoneArgKey ArgKey = "timezone"
+	// LocaleArgKey is the key
+	// of the argument "locale".
+	LocaleArgKey ArgKey = "locale"
+	// UserAgentArgKey is the key
+	// of the argument "userAgent".
+	UserAgentArgKey ArgKey = "userAgent"
+	// CookiesArgKey is the key
+	// of the argument "cookies".
+	CookiesArgKey ArgKey = "cookies"
+	// AllowedURLsArgKey is the key
+	// of the argument "allowedURLs".
+	AllowedURLsArgKey ArgKey = "allowedURLs"
+	// DeniedURLsArgKey is the key
+	// of the argument "deniedURLs".
+	DeniedURLsArgKey ArgKey = "deniedURLs"
+	// BlockedResourceTypesArgKey is the key
+	// of the argument "blockedResourceTypes".
+	BlockedResourceTypesArgKey ArgKey = "blockedResourceTypes"
+	// BlockPrivateNetworksArgKey is the key
+	// of the argument "blockPrivateNetworks".
+	BlockPrivateNetworksArgKey ArgKey = "blockPrivateNetworks"
 )
 
 /*
@@ -101,9 +278,74 @@ func ArgKeys() []ArgKey {
 		GoogleChromeRpccBufferSizeArgKey,
 		ScaleArgKey,
 		WaitForConnectionArgKey,
+		AsyncArgKey,
+		FormatArgKey,
+		QualityArgKey,
+		FullPageArgKey,
+		ClipSelectorArgKey,
+		ViewportWidthArgKey,
+		ViewportHeightArgKey,
+		OrderArgKey,
+		SplitModeArgKey,
+		WatermarkTextArgKey,
+		WatermarkOpacityArgKey,
+		WatermarkRotationArgKey,
+		WatermarkPageRangesArgKey,
+		StampTextArgKey,
+		StampOpacityArgKey,
+		StampRotationArgKey,
+		StampPageRangesArgKey,
+		UserPasswordArgKey,
+		OwnerPasswordArgKey,
+		AllowPrintingArgKey,
+		AllowCopyingArgKey,
+		PDFFormatArgKey,
+		AttachmentsArgKey,
+		AttachmentsRelationshipArgKey,
+		TitleArgKey,
+		AuthorArgKey,
+		SubjectArgKey,
+		KeywordsArgKey,
+		MetadataFromHTMLArgKey,
+		BookmarksArgKey,
+		BookmarkLabelsArgKey,
+		TaggedPDFArgKey,
+		AccessibilityCheckArgKey,
+		ResourceFailurePolicyArgKey,
+		IgnoredStatusCodesArgKey,
+		IgnoredResourceURLsArgKey,
+		FatalResourceURLsArgKey,
+		JavaScriptErrorPolicyArgKey,
+		WaitForSelectorArgKey,
+		WaitForSelectorVisibleArgKey,
+		WaitForExpressionArgKey,
+		EmulatedMediaTypeArgKey,
+		PreferredColorSchemeArgKey,
+		PrintBackgroundArgKey,
+		OmitBackgroundArgKey,
+		PreferCSSPageSizeArgKey,
+		DisplayHeaderFooterArgKey,
+		DeviceScaleFactorArgKey,
+		MobileArgKey,
+		TimezoneArgKey,
+		LocaleArgKey,
+		UserAgentArgKey,
+		CookiesArgKey,
+		AllowedURLsArgKey,
+		DeniedURLsArgKey,
+		BlockedResourceTypesArgKey,
+		BlockPrivateNetworksArgKey,
 	}
 }
 
+/*
+isSecretArgKey returns true if the value
+of the given argument should not be logged.
+*/
+func isSecretArgKey(key ArgKey) bool {
+	return key == UserPasswordArgKey || key == OwnerPasswordArgKey || key == CookiesArgKey
+}
+
 /*
 WaitTimeoutArg is a helper for retrieving
 the "waitTimeout" argument as float64.
@@ -171,6 +413,53 @@ func WaitJSRenderStatusArg(r Resource, config conf.Config) (string, error) {
 	return result, nil
 }
 
+/*
+WaitForSelectorArgs is a helper for retrieving
+the "waitForSelector" argument as string and
+the "waitForSelectorVisible" argument as bool.
+
+The latter requires the former.
+*/
+func WaitForSelectorArgs(r Resource, config conf.Config) (string, bool, error) {
+	const op string = "resource.WaitForSelectorArgs"
+	resolver := func() (string, bool, error) {
+		selector, err := r.StringArg(WaitForSelectorArgKey, "")
+		if err != nil {
+			return "", false, err
+		}
+		visible, err := r.BoolArg(WaitForSelectorVisibleArgKey, false)
+		if err != nil {
+			return "", false, err
+		}
+		if visible && selector == "" {
+			return "", false, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' requires '%s'", WaitForSelectorVisibleArgKey, WaitForSelectorArgKey),
+				nil,
+			)
+		}
+		return selector, visible, nil
+	}
+	selector, visible, err := resolver()
+	if err != nil {
+		return "", false, xerror.New(op, err)
+	}
+	return selector, visible, nil
+}
+
+/*
+WaitForExpressionArg is a helper for retrieving
+the "waitForExpression" argument as string.
+*/
+func WaitForExpressionArg(r Resource, config conf.Config) (string, error) {
+	const op string = "resource.WaitForExpressionArg"
+	result, err := r.StringArg(WaitForExpressionArgKey, "")
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
 /*
 WebhookURLTimeoutArg is a helper for retrieving
 the "webhookURLTimeout" argument as float64.
@@ -355,3 +644,894 @@ func ScaleArg(r Resource, config conf.Config) (float64, error) {
 	}
 	return result, nil
 }
+
+/*
+ScreenshotFormatArg is a helper for retrieving
+the "format" argument as string.
+
+It also validates it against the available
+screenshot formats.
+*/
+func ScreenshotFormatArg(r Resource, config conf.Config) (string, error) {
+	const op string = "resource.ScreenshotFormatArg"
+	opts := printer.DefaultScreenshotOptions()
+	result, err := r.StringArg(
+		FormatArgKey,
+		opts.Format,
+		xassert.StringOneOf(printer.ScreenshotFormats()),
+	)
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+QualityArg is a helper for retrieving
+the "quality" argument as int64.
+*/
+func QualityArg(r Resource, config conf.Config) (int64, error) {
+	const op string = "resource.QualityArg"
+	opts := printer.DefaultScreenshotOptions()
+	result, err := r.Int64Arg(
+		QualityArgKey,
+		opts.Quality,
+		xassert.Int64NotInferiorTo(0),
+		xassert.Int64NotSuperiorTo(100),
+	)
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+DeviceScaleFactorArg is a helper for retrieving
+the "deviceScaleFactor" argument as float64.
+*/
+func DeviceScaleFactorArg(r Resource, config conf.Config) (float64, error) {
+	const op string = "resource.DeviceScaleFactorArg"
+	opts := printer.DefaultChromePrinterOptions(config)
+	result, err := r.Float64Arg(
+		DeviceScaleFactorArgKey,
+		opts.DeviceScaleFactor,
+		xassert.Float64NotInferiorTo(0.1),
+		xassert.Float64NotSuperiorTo(10.0),
+	)
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+TimezoneArg is a helper for retrieving
+the "timezone" argument as string.
+
+It returns an empty string if the
+argument does not exist.
+*/
+func TimezoneArg(r Resource) (string, error) {
+	const op string = "resource.TimezoneArg"
+	if !r.HasArg(TimezoneArgKey) {
+		return "", nil
+	}
+	result, err := r.StringArg(TimezoneArgKey, "", xassert.StringTimezone())
+	if err != nil {
+		return "", xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+LocaleArg is a helper for retrieving
+the "locale" argument as string.
+
+It returns an empty string if the
+argument does not exist.
+*/
+func LocaleArg(r Resource) (string, error) {
+	const op string = "resource.LocaleArg"
+	if !r.HasArg(LocaleArgKey) {
+		return "", nil
+	}
+	result, err := r.StringArg(LocaleArgKey, "", xassert.StringLanguageTag())
+	if err != nil {
+		return "", xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+CookiesArg is a helper for retrieving the
+"cookies" argument, a JSON array of cookies,
+e.g. [{"name":"session","value":"foo","domain":".example.com"}].
+*/
+func CookiesArg(r Resource) ([]printer.Cookie, error) {
+	const op string = "resource.CookiesArg"
+	resolver := func() ([]printer.Cookie, error) {
+		if !r.HasArg(CookiesArgKey) {
+			return nil, nil
+		}
+		var cookies []printer.Cookie
+		if err := json.Unmarshal([]byte(r.args[CookiesArgKey]), &cookies); err != nil {
+			return nil, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' should be a JSON array of cookies, e.g. [{\"name\":\"session\",\"value\":\"foo\"}]", CookiesArgKey),
+				err,
+			)
+		}
+		for _, cookie := range cookies {
+			if cookie.Name == "" || strings.ContainsAny(cookie.Name, "=; \t\r\n") {
+				return nil, xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' has a cookie with a wrong name, got '%s'", CookiesArgKey, cookie.Name),
+					nil,
+				)
+			}
+			if strings.ContainsAny(cookie.Value, ";\r\n") {
+				return nil, xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' has a cookie '%s' with a wrong value", CookiesArgKey, cookie.Name),
+					nil,
+				)
+			}
+			if cookie.SameSite != "" {
+				if _, err := xassert.String(string(CookiesArgKey), cookie.SameSite, "", xassert.StringOneOf(printer.CookieSameSites())); err != nil {
+					return nil, err
+				}
+			}
+			// Google Chrome rejects the cookies with
+			// the "None" SameSite attribute which are
+			// not secure.
+			if cookie.SameSite == printer.NoneCookieSameSite && !cookie.Secure {
+				return nil, xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' has a cookie '%s' with the SameSite attribute '%s' which is not secure", CookiesArgKey, cookie.Name, cookie.SameSite),
+					nil,
+				)
+			}
+			if cookie.Expires < 0 {
+				return nil, xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' has a cookie '%s' with a negative expiration date", CookiesArgKey, cookie.Name),
+					nil,
+				)
+			}
+		}
+		return cookies, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+ViewportArgs is a helper for retrieving
+the "viewportWidth" and "viewportHeight"
+arguments as int64.
+*/
+func ViewportArgs(r Resource, config conf.Config) (int64, int64, error) {
+	const op string = "resource.ViewportArgs"
+	opts := printer.DefaultChromePrinterOptions(config)
+	resolver := func() (int64, int64, error) {
+		viewportWidth, err := r.Int64Arg(
+			ViewportWidthArgKey,
+			opts.ViewportWidth,
+			xassert.Int64NotInferiorTo(1),
+		)
+		if err != nil {
+			return opts.ViewportWidth,
+				opts.ViewportHeight,
+				err
+		}
+		viewportHeight, err := r.Int64Arg(
+			ViewportHeightArgKey,
+			opts.ViewportHeight,
+			xassert.Int64NotInferiorTo(1),
+		)
+		if err != nil {
+			return opts.ViewportWidth,
+				opts.ViewportHeight,
+				err
+		}
+		return viewportWidth,
+			viewportHeight,
+			nil
+	}
+	viewportWidth, viewportHeight,
+		err := resolver()
+	if err != nil {
+		return viewportWidth,
+			viewportHeight,
+			xerror.New(op, err)
+	}
+	return viewportWidth,
+		viewportHeight,
+		nil
+}
+
+/*
+SplitArgs is a helper for retrieving
+the "splitMode" argument as string and
+the "pageRanges" argument as a slice of
+xpdf.PageRange.
+
+The "pageRanges" argument is required
+if the split mode is "ranges".
+*/
+func SplitArgs(r Resource, config conf.Config) (string, []xpdf.PageRange, error) {
+	const op string = "resource.SplitArgs"
+	opts := printer.DefaultSplitPrinterOptions(config)
+	resolver := func() (string, []xpdf.PageRange, error) {
+		mode, err := r.StringArg(
+			SplitModeArgKey,
+			opts.Mode,
+			xassert.StringOneOf(printer.SplitModes()),
+		)
+		if err != nil {
+			return opts.Mode, opts.PageRanges, err
+		}
+		if mode != printer.RangesSplitMode {
+			return mode, opts.PageRanges, nil
+		}
+		if !r.HasArg(PageRangesArgKey) {
+			return mode,
+				opts.PageRanges,
+				xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' not found or empty", PageRangesArgKey),
+					nil,
+				)
+		}
+		value, err := r.StringArg(PageRangesArgKey, "")
+		if err != nil {
+			return mode, opts.PageRanges, err
+		}
+		pageRanges, err := xpdf.ParsePageRanges(value)
+		if err != nil {
+			return mode, opts.PageRanges, err
+		}
+		return mode, pageRanges, nil
+	}
+	mode, pageRanges, err := resolver()
+	if err != nil {
+		return mode, pageRanges, xerror.New(op, err)
+	}
+	return mode, pageRanges, nil
+}
+
+/*
+OverlayArgs is a helper for retrieving
+the watermark and the stamp (if any) as
+a slice of xpdf.Overlay, the watermark
+first.
+
+A watermark comes from the "watermarkText"
+argument or the "watermark.pdf" file, a
+stamp from the "stampText" argument or
+the "stamp.pdf" file.
+*/
+func OverlayArgs(r Resource, config conf.Config) ([]xpdf.Overlay, error) {
+	const op string = "resource.OverlayArgs"
+	resolver := func() ([]xpdf.Overlay, error) {
+		var overlays []xpdf.Overlay
+		watermark, ok, err := overlayArgs(
+			r,
+			WatermarkFilename,
+			WatermarkTextArgKey,
+			WatermarkOpacityArgKey,
+			WatermarkRotationArgKey,
+			WatermarkPageRangesArgKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			overlays = append(overlays, watermark)
+		}
+		stamp, ok, err := overlayArgs(
+			r,
+			StampFilename,
+			StampTextArgKey,
+			StampOpacityArgKey,
+			StampRotationArgKey,
+			StampPageRangesArgKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			stamp.OnTop = true
+			overlays = append(overlays, stamp)
+		}
+		return overlays, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+overlayArgs retrieves an xpdf.Overlay from
+the given file and arguments. It returns
+false if neither the file nor the text
+argument exists.
+*/
+func overlayArgs(r Resource, filename string, textKey, opacityKey, rotationKey, pageRangesKey ArgKey) (xpdf.Overlay, bool, error) {
+	const (
+		op              string  = "resource.overlayArgs"
+		defaultOpacity  float64 = 0.5
+		defaultRotation float64 = 0.0
+	)
+	fpath, fpathErr := r.Fpath(filename)
+	hasFile := fpathErr == nil
+	if !hasFile && !r.HasArg(textKey) {
+		return xpdf.Overlay{}, false, nil
+	}
+	if hasFile && r.HasArg(textKey) {
+		return xpdf.Overlay{}, false, xerror.Invalid(
+			op,
+			fmt.Sprintf("'%s' and '%s' cannot be used together", filename, textKey),
+			nil,
+		)
+	}
+	text, err := r.StringArg(textKey, "")
+	if err != nil {
+		return xpdf.Overlay{}, false, err
+	}
+	opacity, err := r.Float64Arg(
+		opacityKey,
+		defaultOpacity,
+		xassert.Float64NotInferiorTo(0.0),
+		xassert.Float64NotSuperiorTo(1.0),
+	)
+	if err != nil {
+		return xpdf.Overlay{}, false, err
+	}
+	rotation, err := r.Float64Arg(
+		rotationKey,
+		defaultRotation,
+		xassert.Float64NotInferiorTo(-180.0),
+		xassert.Float64NotSuperiorTo(180.0),
+	)
+	if err != nil {
+		return xpdf.Overlay{}, false, err
+	}
+	var pageRanges []xpdf.PageRange
+	if r.HasArg(pageRangesKey) {
+		value, err := r.StringArg(pageRangesKey, "")
+		if err != nil {
+			return xpdf.Overlay{}, false, err
+		}
+		pageRanges, err = xpdf.ParsePageRanges(value)
+		if err != nil {
+			return xpdf.Overlay{}, false, err
+		}
+	}
+	if !hasFile {
+		fpath = ""
+	}
+	return xpdf.Overlay{
+		Text:       text,
+		Fpath:      fpath,
+		Opacity:    opacity,
+		Rotation:   rotation,
+		PageRanges: pageRanges,
+	}, true, nil
+}
+
+/*
+EncryptionArgs is a helper for retrieving
+the "userPassword", "ownerPassword",
+"allowPrinting" and "allowCopying" arguments
+as an xpdf.Encryption.
+
+It returns false if there is no password,
+i.e., if the resulting PDF file should not
+be encrypted.
+*/
+func EncryptionArgs(r Resource, config conf.Config) (xpdf.Encryption, bool, error) {
+	const op string = "resource.EncryptionArgs"
+	resolver := func() (xpdf.Encryption, bool, error) {
+		allowPrinting, err := r.BoolArg(AllowPrintingArgKey, true)
+		if err != nil {
+			return xpdf.Encryption{}, false, err
+		}
+		allowCopying, err := r.BoolArg(AllowCopyingArgKey, true)
+		if err != nil {
+			return xpdf.Encryption{}, false, err
+		}
+		// permissions may only be enforced
+		// thanks to an owner password.
+		if (!allowPrinting || !allowCopying) && !r.HasArg(OwnerPasswordArgKey) {
+			return xpdf.Encryption{}, false, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' is required for restricting permissions", OwnerPasswordArgKey),
+				nil,
+			)
+		}
+		if !r.HasArg(UserPasswordArgKey) && !r.HasArg(OwnerPasswordArgKey) {
+			return xpdf.Encryption{}, false, nil
+		}
+		userPassword, err := r.StringArg(UserPasswordArgKey, "")
+		if err != nil {
+			return xpdf.Encryption{}, false, err
+		}
+		ownerPassword, err := r.StringArg(OwnerPasswordArgKey, "")
+		if err != nil {
+			return xpdf.Encryption{}, false, err
+		}
+		return xpdf.Encryption{
+			UserPassword:  userPassword,
+			OwnerPassword: ownerPassword,
+			AllowPrinting: allowPrinting,
+			AllowCopying:  allowCopying,
+		}, true, nil
+	}
+	encryption, ok, err := resolver()
+	if err != nil {
+		return encryption, ok, xerror.New(op, err)
+	}
+	return encryption, ok, nil
+}
+
+/*
+PDFFormatArg is a helper for retrieving
+the "pdfFormat" argument as string.
+
+It defaults to PDF/A-3b if there is a
+"factur-x.xml" file, as a Factur-X invoice
+requires it.
+
+It also validates it against the available
+PDF formats and the application configuration,
+as the conversion to PDF/A requires unoconv.
+*/
+func PDFFormatArg(r Resource, config conf.Config) (string, error) {
+	const op string = "resource.PDFFormatArg"
+	resolver := func() (string, error) {
+		_, err := r.Fpath(FacturXFilename)
+		facturX := err == nil
+		if !r.HasArg(PDFFormatArgKey) && !facturX {
+			return "", nil
+		}
+		defaultValue := ""
+		if facturX {
+			defaultValue = xpdf.PDFA3b
+		}
+		result, err := r.StringArg(
+			PDFFormatArgKey,
+			defaultValue,
+			xassert.StringOneOf(xpdf.PDFFormats()),
+		)
+		if err != nil {
+			return "", err
+		}
+		if facturX && result != xpdf.PDFA3b {
+			return "", xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' requires '%s' instead of '%s'", FacturXFilename, xpdf.PDFA3b, result),
+				nil,
+			)
+		}
+		if config.DisableUnoconv() {
+			return "", xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' cannot be satisfied as unoconv is disabled", result),
+				nil,
+			)
+		}
+		return result, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+AttachmentArgs is a helper for retrieving
+the files to embed into the resulting PDF
+file as a slice of xpdf.Attachment.
+
+The "factur-x.xml" file (if any) comes
+first, then the files listed in the
+"attachments" argument with the
+AFRelationship of the
+"attachmentsRelationship" argument.
+*/
+func AttachmentArgs(r Resource, config conf.Config) ([]xpdf.Attachment, error) {
+	const op string = "resource.AttachmentArgs"
+	resolver := func() ([]xpdf.Attachment, error) {
+		relationship, err := r.StringArg(
+			AttachmentsRelationshipArgKey,
+			xpdf.UnspecifiedRelationship,
+			xassert.StringOneOf(xpdf.Relationships()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		var attachments []xpdf.Attachment
+		if fpath, err := r.Fpath(FacturXFilename); err == nil {
+			attachments = append(attachments, xpdf.Attachment{Fpath: fpath})
+		}
+		filenames, err := r.attachmentFilenames()
+		if err != nil {
+			return nil, err
+		}
+		for _, filename := range filenames {
+			if filename == FacturXFilename {
+				continue
+			}
+			attachments = append(attachments, xpdf.Attachment{
+				Fpath:        r.files[filename].fpath,
+				Relationship: relationship,
+			})
+		}
+		return attachments, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+MetadataArgs is a helper for retrieving
+the "title", "author", "subject" and
+"keywords" arguments as an xpdf.Metadata.
+*/
+func MetadataArgs(r Resource, config conf.Config) (xpdf.Metadata, error) {
+	const op string = "resource.MetadataArgs"
+	resolver := func() (xpdf.Metadata, error) {
+		title, err := r.StringArg(TitleArgKey, "")
+		if err != nil {
+			return xpdf.Metadata{}, err
+		}
+		author, err := r.StringArg(AuthorArgKey, "")
+		if err != nil {
+			return xpdf.Metadata{}, err
+		}
+		subject, err := r.StringArg(SubjectArgKey, "")
+		if err != nil {
+			return xpdf.Metadata{}, err
+		}
+		keywords, err := r.StringArg(KeywordsArgKey, "")
+		if err != nil {
+			return xpdf.Metadata{}, err
+		}
+		return xpdf.Metadata{
+			Title:    title,
+			Author:   author,
+			Subject:  subject,
+			Keywords: keywords,
+		}, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+BookmarksArg is a helper for retrieving
+the "bookmarks" argument as bool.
+
+It defaults to true if there is a
+"bookmarkLabels" argument.
+*/
+func BookmarksArg(r Resource, config conf.Config) (bool, error) {
+	const op string = "resource.BookmarksArg"
+	result, err := r.BoolArg(BookmarksArgKey, r.HasArg(BookmarkLabelsArgKey))
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+BookmarkTitlesArg is a helper for retrieving
+the titles of the bookmarks of the given
+files, or nil if the "bookmarks" argument
+is false.
+
+The "bookmarkLabels" argument is a JSON object
+of titles by filename, e.g. {"cover.pdf":"Cover"}.
+A file without label is titled after its
+filename, without extension.
+*/
+func BookmarkTitlesArg(r Resource, config conf.Config, fpaths []string) ([]string, error) {
+	const op string = "resource.BookmarkTitlesArg"
+	resolver := func() ([]string, error) {
+		bookmarks, err := BookmarksArg(r, config)
+		if err != nil {
+			return nil, err
+		}
+		if !bookmarks {
+			return nil, nil
+		}
+		labels := make(map[string]string)
+		if r.HasArg(BookmarkLabelsArgKey) {
+			if err := json.Unmarshal([]byte(r.args[BookmarkLabelsArgKey]), &labels); err != nil {
+				return nil, xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' should be a JSON object of titles by filename, e.g. {\"cover.pdf\":\"Cover\"}", BookmarkLabelsArgKey),
+					err,
+				)
+			}
+		}
+		for filename := range labels {
+			if _, ok := r.files[filename]; !ok {
+				return nil, xerror.Invalid(
+					op,
+					fmt.Sprintf("resource file '%s' from '%s' does not exist", filename, BookmarkLabelsArgKey),
+					nil,
+				)
+			}
+		}
+		// the paths use the normalized filenames,
+		// while the labels use the original ones.
+		filenames := make(map[string]string)
+		for filename, file := range r.files {
+			filenames[file.fpath] = filename
+		}
+		titles := make([]string, len(fpaths))
+		for i, fpath := range fpaths {
+			filename, ok := filenames[fpath]
+			if !ok {
+				filename = filepath.Base(fpath)
+			}
+			if label, ok := labels[filename]; ok {
+				titles[i] = label
+				continue
+			}
+			titles[i] = strings.TrimSuffix(filename, filepath.Ext(filename))
+		}
+		return titles, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+ResourceFailurePolicyArg is a helper for
+retrieving the "resourceFailurePolicy"
+argument as string.
+*/
+func ResourceFailurePolicyArg(r Resource, config conf.Config) (string, error) {
+	const op string = "resource.ResourceFailurePolicyArg"
+	result, err := r.StringArg(
+		ResourceFailurePolicyArgKey,
+		printer.AllResourceFailurePolicy,
+		xassert.StringOneOf(printer.ResourceFailurePolicies()),
+	)
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+IgnoredStatusCodesArg is a helper for retrieving
+the "ignoredStatusCodes" argument, a JSON array
+of HTTP error status codes, e.g. [404,410].
+*/
+func IgnoredStatusCodesArg(r Resource, config conf.Config) ([]int, error) {
+	const op string = "resource.IgnoredStatusCodesArg"
+	resolver := func() ([]int, error) {
+		if !r.HasArg(IgnoredStatusCodesArgKey) {
+			return nil, nil
+		}
+		var statusCodes []int
+		if err := json.Unmarshal([]byte(r.args[IgnoredStatusCodesArgKey]), &statusCodes); err != nil {
+			return nil, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' should be a JSON array of HTTP status codes, e.g. [404,410]", IgnoredStatusCodesArgKey),
+				err,
+			)
+		}
+		for _, statusCode := range statusCodes {
+			if statusCode < 400 || statusCode > 599 {
+				return nil, xerror.Invalid(
+					op,
+					fmt.Sprintf("'%d' from '%s' is not an HTTP error status code", statusCode, IgnoredStatusCodesArgKey),
+					nil,
+				)
+			}
+		}
+		return statusCodes, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+ResourceURLPatternsArgs is a helper for retrieving
+the "ignoredResourceURLs" and "fatalResourceURLs"
+arguments, JSON arrays of URL patterns where "*"
+matches any sequence of characters, e.g.
+["https://*.example.com/*"].
+*/
+func ResourceURLPatternsArgs(r Resource, config conf.Config) ([]string, []string, error) {
+	const op string = "resource.ResourceURLPatternsArgs"
+	ignored, err := urlPatternsArg(r, IgnoredResourceURLsArgKey)
+	if err != nil {
+		return nil, nil, xerror.New(op, err)
+	}
+	fatal, err := urlPatternsArg(r, FatalResourceURLsArgKey)
+	if err != nil {
+		return nil, nil, xerror.New(op, err)
+	}
+	return ignored, fatal, nil
+}
+
+/*
+urlPatternsArg retrieves the argument identified
+by given key as a JSON array of URL patterns.
+*/
+func urlPatternsArg(r Resource, key ArgKey) ([]string, error) {
+	const op string = "resource.urlPatternsArg"
+	if !r.HasArg(key) {
+		return nil, nil
+	}
+	var result []string
+	if err := json.Unmarshal([]byte(r.args[key]), &result); err != nil {
+		return nil, xerror.Invalid(
+			op,
+			fmt.Sprintf("'%s' should be a JSON array of URL patterns, e.g. [\"https://*.example.com/*\"]", key),
+			err,
+		)
+	}
+	for _, pattern := range result {
+		if strings.TrimSpace(pattern) == "" {
+			return nil, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' should not have an empty URL pattern", key),
+				nil,
+			)
+		}
+	}
+	return result, nil
+}
+
+/*
+RequestFiltersArgs is a helper for retrieving
+the request filters: the one from the
+configuration, and the one from the
+"allowedURLs", "deniedURLs" (JSON arrays
+of URL patterns), "blockedResourceTypes"
+(JSON array of resource types) and
+"blockPrivateNetworks" arguments.
+
+As a request is blocked if any filter blocks
+it, the arguments may only block more
+requests than the configuration.
+*/
+func RequestFiltersArgs(r Resource, config conf.Config) ([]printer.RequestFilter, error) {
+	const op string = "resource.RequestFiltersArgs"
+	resolver := func() ([]printer.RequestFilter, error) {
+		filters := []printer.RequestFilter{printer.ConfigRequestFilter(config)}
+		allowed, err := urlPatternsArg(r, AllowedURLsArgKey)
+		if err != nil {
+			return nil, err
+		}
+		denied, err := urlPatternsArg(r, DeniedURLsArgKey)
+		if err != nil {
+			return nil, err
+		}
+		var resourceTypes []string
+		if r.HasArg(BlockedResourceTypesArgKey) {
+			if err := json.Unmarshal([]byte(r.args[BlockedResourceTypesArgKey]), &resourceTypes); err != nil {
+				return nil, xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' should be a JSON array of resource types, e.g. [\"%s\"]", BlockedResourceTypesArgKey, conf.ImageResourceType),
+					err,
+				)
+			}
+			for _, resourceType := range resourceTypes {
+				if _, err := xassert.String(
+					string(BlockedResourceTypesArgKey),
+					resourceType,
+					"",
+					xassert.StringOneOf(conf.ResourceTypes()),
+				); err != nil {
+					return nil, err
+				}
+			}
+		}
+		blockPrivateNetworks, err := r.BoolArg(BlockPrivateNetworksArgKey, false)
+		if err != nil {
+			return nil, err
+		}
+		filter := printer.RequestFilter{
+			AllowedURLs:          allowed,
+			DeniedURLs:           denied,
+			BlockedResourceTypes: resourceTypes,
+			BlockPrivateNetworks: blockPrivateNetworks,
+		}
+		if !filter.IsEmpty() {
+			filters = append(filters, filter)
+		}
+		return filters, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+JavaScriptErrorPolicyArg is a helper for
+retrieving the "javascriptErrorPolicy"
+argument as string.
+*/
+func JavaScriptErrorPolicyArg(r Resource, config conf.Config) (string, error) {
+	const op string = "resource.JavaScriptErrorPolicyArg"
+	result, err := r.StringArg(
+		JavaScriptErrorPolicyArgKey,
+		printer.FailOnExceptionJavaScriptErrorPolicy,
+		xassert.StringOneOf(printer.JavaScriptErrorPolicies()),
+	)
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+MediaArgs is a helper for retrieving the
+"emulatedMediaType" and "preferredColorScheme"
+arguments as string.
+
+They are empty if Google Chrome should
+not emulate them.
+*/
+func MediaArgs(r Resource, config conf.Config) (string, string, error) {
+	const op string = "resource.MediaArgs"
+	resolver := func() (string, string, error) {
+		var mediaType, colorScheme string
+		if r.HasArg(EmulatedMediaTypeArgKey) {
+			v, err := r.StringArg(
+				EmulatedMediaTypeArgKey,
+				"",
+				xassert.StringOneOf(printer.MediaTypes()),
+			)
+			if err != nil {
+				return "", "", err
+			}
+			mediaType = v
+		}
+		if r.HasArg(PreferredColorSchemeArgKey) {
+			v, err := r.StringArg(
+				PreferredColorSchemeArgKey,
+				"",
+				xassert.StringOneOf(printer.ColorSchemes()),
+			)
+			if err != nil {
+				return "", "", err
+			}
+			colorScheme = v
+		}
+		return mediaType, colorScheme, nil
+	}
+	mediaType, colorScheme, err := resolver()
+	if err != nil {
+		return "", "", xerror.New(op, err)
+	}
+	return mediaType, colorScheme, nil
+}
@@ -1,11 +1,15 @@
 package resource
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/printer"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xpdf"
 	"github.com/thecodingmachine/gotenberg/test"
 )
 
@@ -14,9 +18,12 @@ func TestArgKeys(t *testing.T) {
 		ResultFilenameArgKey,
 		WaitTimeoutArgKey,
 		WebhookURLArgKey,
+		WebhookURLTimeoutArgKey,
 		WebhookURLMethodArgKey,
+		WebhookErrorURLArgKey,
 		RemoteURLArgKey,
 		WaitDelayArgKey,
+		WaitJSRenderStatusArgKey,
 		PaperWidthArgKey,
 		PaperHeightArgKey,
 		MarginTopArgKey,
@@ -27,10 +34,75 @@ func TestArgKeys(t *testing.T) {
 		PageRangesArgKey,
 		GoogleChromeRpccBufferSizeArgKey,
 		ScaleArgKey,
+		WaitForConnectionArgKey,
+		AsyncArgKey,
+		FormatArgKey,
+		QualityArgKey,
+		FullPageArgKey,
+		ClipSelectorArgKey,
+		ViewportWidthArgKey,
+		ViewportHeightArgKey,
+		OrderArgKey,
+		SplitModeArgKey,
+		WatermarkTextArgKey,
+		WatermarkOpacityArgKey,
+		WatermarkRotationArgKey,
+		WatermarkPageRangesArgKey,
+		StampTextArgKey,
+		StampOpacityArgKey,
+		StampRotationArgKey,
+		StampPageRangesArgKey,
+		UserPasswordArgKey,
+		OwnerPasswordArgKey,
+		AllowPrintingArgKey,
+		AllowCopyingArgKey,
+		PDFFormatArgKey,
+		AttachmentsArgKey,
+		AttachmentsRelationshipArgKey,
+		TitleArgKey,
+		AuthorArgKey,
+		SubjectArgKey,
+		KeywordsArgKey,
+		MetadataFromHTMLArgKey,
+		BookmarksArgKey,
+		BookmarkLabelsArgKey,
+		TaggedPDFArgKey,
+		AccessibilityCheckArgKey,
+		ResourceFailurePolicyArgKey,
+		IgnoredStatusCodesArgKey,
+		IgnoredResourceURLsArgKey,
+		FatalResourceURLsArgKey,
+		JavaScriptErrorPolicyArgKey,
+		WaitForSelectorArgKey,
+		WaitForSelectorVisibleArgKey,
+		WaitForExpressionArgKey,
+		EmulatedMediaTypeArgKey,
+		PreferredColorSchemeArgKey,
+		PrintBackgroundArgKey,
+		OmitBackgroundArgKey,
+		PreferCSSPageSizeArgKey,
+		DisplayHeaderFooterArgKey,
+		DeviceScaleFactorArgKey,
+		MobileArgKey,
+		TimezoneArgKey,
+		LocaleArgKey,
+		UserAgentArgKey,
+		CookiesArgKey,
+		AllowedURLsArgKey,
+		DeniedURLsArgKey,
+		BlockedResourceTypesArgKey,
+		BlockPrivateNetworksArgKey,
 	}
 	assert.Equal(t, expected, ArgKeys())
 }
 
+func TestIsSecretArgKey(t *testing.T) {
+	assert.True(t, isSecretArgKey(UserPasswordArgKey))
+	assert.True(t, isSecretArgKey(OwnerPasswordArgKey))
+	assert.True(t, isSecretArgKey(CookiesArgKey))
+	assert.False(t, isSecretArgKey(TitleArgKey))
+}
+
 func TestWaitTimeoutArg(t *testing.T) {
 	const resourceDirectoryName string = "foo"
 	var expected float64
@@ -75,6 +147,39 @@ func TestWaitTimeoutArg(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestWaitForSelectorArgs(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// arguments do not exist.
+	selector, visible, err := WaitForSelectorArgs(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, "", selector)
+	assert.False(t, visible)
+	// should not be OK as "waitForSelectorVisible"
+	// requires "waitForSelector".
+	r.WithArg(WaitForSelectorVisibleArgKey, "true")
+	_, _, err = WaitForSelectorArgs(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// arguments exist.
+	r.WithArg(WaitForSelectorArgKey, "#chart canvas")
+	selector, visible, err = WaitForSelectorArgs(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, "#chart canvas", selector)
+	assert.True(t, visible)
+	// should not be OK as "waitForSelectorVisible"
+	// is not a boolean.
+	r.WithArg(WaitForSelectorVisibleArgKey, "foo")
+	_, _, err = WaitForSelectorArgs(r, config)
+	test.AssertError(t, err)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
 func TestWaitDelayArg(t *testing.T) {
 	const (
 		resourceDirectoryName string  = "foo"
@@ -394,3 +499,747 @@ func TestScaleArg(t *testing.T) {
 	err = r.Close()
 	assert.Nil(t, err)
 }
+
+func TestScreenshotFormatArg(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	var expected string
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	opts := printer.DefaultScreenshotOptions()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	expected = opts.Format
+	v, err := ScreenshotFormatArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// argument exist.
+	expected = printer.WebPScreenshotFormat
+	r.WithArg(FormatArgKey, printer.WebPScreenshotFormat)
+	v, err = ScreenshotFormatArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as argument
+	// value is not a screenshot format.
+	expected = opts.Format
+	r.WithArg(FormatArgKey, "gif")
+	v, err = ScreenshotFormatArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, v)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestQualityArg(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	var expected int64
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	opts := printer.DefaultScreenshotOptions()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	expected = opts.Quality
+	v, err := QualityArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// argument exist.
+	expected = 50
+	r.WithArg(QualityArgKey, "50")
+	v, err = QualityArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as argument
+	// value is < 0.
+	expected = opts.Quality
+	r.WithArg(QualityArgKey, "-1")
+	v, err = QualityArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as argument
+	// value is > 100.
+	expected = opts.Quality
+	r.WithArg(QualityArgKey, "101")
+	v, err = QualityArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as
+	// argument value is invalid.
+	expected = opts.Quality
+	r.WithArg(QualityArgKey, "foo")
+	v, err = QualityArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, v)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestViewportArgs(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	var expected int64
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	opts := printer.DefaultChromePrinterOptions(config)
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// arguments do not exist.
+	width, height, err := ViewportArgs(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, opts.ViewportWidth, width)
+	assert.Equal(t, opts.ViewportHeight, height)
+	// arguments exist.
+	expected = 1200
+	r.WithArg(ViewportWidthArgKey, "1200")
+	r.WithArg(ViewportHeightArgKey, "1200")
+	width, height, err = ViewportArgs(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, width)
+	assert.Equal(t, expected, height)
+	// should not be OK as arguments
+	// value are < 1.
+	expected = opts.ViewportWidth
+	r.WithArg(ViewportWidthArgKey, "0")
+	width, _, err = ViewportArgs(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, width)
+	r.WithArg(ViewportWidthArgKey, "1200")
+	expected = opts.ViewportHeight
+	r.WithArg(ViewportHeightArgKey, "0")
+	_, height, err = ViewportArgs(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, height)
+	r.WithArg(ViewportHeightArgKey, "1200")
+	// should not be OK as
+	// arguments value are invalids.
+	expected = opts.ViewportWidth
+	r.WithArg(ViewportWidthArgKey, "foo")
+	width, _, err = ViewportArgs(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, width)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestDeviceScaleFactorArg(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	v, err := DeviceScaleFactorArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, v)
+	// argument exists.
+	r.WithArg(DeviceScaleFactorArgKey, "2")
+	v, err = DeviceScaleFactorArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, 2.0, v)
+	// should not be OK as argument
+	// value is < 0.1.
+	r.WithArg(DeviceScaleFactorArgKey, "0")
+	_, err = DeviceScaleFactorArg(r, config)
+	test.AssertError(t, err)
+	// should not be OK as argument
+	// value is > 10.
+	r.WithArg(DeviceScaleFactorArgKey, "11")
+	_, err = DeviceScaleFactorArg(r, config)
+	test.AssertError(t, err)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestTimezoneArg(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	v, err := TimezoneArg(r)
+	assert.Nil(t, err)
+	assert.Equal(t, "", v)
+	// argument exists.
+	r.WithArg(TimezoneArgKey, "America/New_York")
+	v, err = TimezoneArg(r)
+	assert.Nil(t, err)
+	assert.Equal(t, "America/New_York", v)
+	// should not be OK as argument
+	// value is not an IANA time zone.
+	r.WithArg(TimezoneArgKey, "EST5")
+	_, err = TimezoneArg(r)
+	test.AssertError(t, err)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestLocaleArg(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	v, err := LocaleArg(r)
+	assert.Nil(t, err)
+	assert.Equal(t, "", v)
+	// argument exists.
+	r.WithArg(LocaleArgKey, "de-CH")
+	v, err = LocaleArg(r)
+	assert.Nil(t, err)
+	assert.Equal(t, "de-CH", v)
+	// should not be OK as argument
+	// value is not a BCP 47 tag.
+	r.WithArg(LocaleArgKey, "german")
+	_, err = LocaleArg(r)
+	test.AssertError(t, err)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestCookiesArg(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	cookies, err := CookiesArg(r)
+	assert.Nil(t, err)
+	assert.Nil(t, cookies)
+	// argument exists.
+	r.WithArg(CookiesArgKey, `[{"name":"session","value":"foo","domain":".example.com","secure":true,"httpOnly":true,"sameSite":"None"},{"name":"theme","value":"dark"}]`)
+	cookies, err = CookiesArg(r)
+	assert.Nil(t, err)
+	assert.Equal(t, []printer.Cookie{
+		{Name: "session", Value: "foo", Domain: ".example.com", Secure: true, HTTPOnly: true, SameSite: printer.NoneCookieSameSite},
+		{Name: "theme", Value: "dark"},
+	}, cookies)
+	// should not be OK as argument
+	// value is not a JSON array.
+	r.WithArg(CookiesArgKey, "session=foo")
+	_, err = CookiesArg(r)
+	test.AssertError(t, err)
+	// should not be OK as a cookie
+	// has no name.
+	r.WithArg(CookiesArgKey, `[{"value":"foo"}]`)
+	_, err = CookiesArg(r)
+	test.AssertError(t, err)
+	// should not be OK as a cookie
+	// value has a semicolon.
+	r.WithArg(CookiesArgKey, `[{"name":"session","value":"foo; path=/"}]`)
+	_, err = CookiesArg(r)
+	test.AssertError(t, err)
+	// should not be OK as a SameSite
+	// attribute is wrong.
+	r.WithArg(CookiesArgKey, `[{"name":"session","value":"foo","sameSite":"foo"}]`)
+	_, err = CookiesArg(r)
+	test.AssertError(t, err)
+	// should not be OK as a cookie with
+	// SameSite "None" is not secure.
+	r.WithArg(CookiesArgKey, `[{"name":"session","value":"foo","sameSite":"None"}]`)
+	_, err = CookiesArg(r)
+	test.AssertError(t, err)
+	// should not be OK as an expiration
+	// date is negative.
+	r.WithArg(CookiesArgKey, `[{"name":"session","value":"foo","expires":-1}]`)
+	_, err = CookiesArg(r)
+	test.AssertError(t, err)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestRequestFiltersArgs(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// arguments do not exist.
+	filters, err := RequestFiltersArgs(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, []printer.RequestFilter{printer.ConfigRequestFilter(config)}, filters)
+	// arguments exist.
+	r.WithArg(AllowedURLsArgKey, `["https://*.example.com/*"]`)
+	r.WithArg(DeniedURLsArgKey, `["https://tracker.example.com/*"]`)
+	r.WithArg(BlockedResourceTypesArgKey, `["font","media"]`)
+	r.WithArg(BlockPrivateNetworksArgKey, "true")
+	filters, err = RequestFiltersArgs(r, config)
+	assert.Nil(t, err)
+	assert.Len(t, filters, 2)
+	assert.Equal(t, printer.RequestFilter{
+		AllowedURLs:          []string{"https://*.example.com/*"},
+		DeniedURLs:           []string{"https://tracker.example.com/*"},
+		BlockedResourceTypes: []string{conf.FontResourceType, conf.MediaResourceType},
+		BlockPrivateNetworks: true,
+	}, filters[1])
+	// should not be OK as "allowedURLs"
+	// is not a JSON array.
+	r.WithArg(AllowedURLsArgKey, "https://*.example.com/*")
+	_, err = RequestFiltersArgs(r, config)
+	test.AssertError(t, err)
+	r.WithArg(AllowedURLsArgKey, "")
+	// should not be OK as "blockedResourceTypes"
+	// has a wrong resource type.
+	r.WithArg(BlockedResourceTypesArgKey, `["font","foo"]`)
+	_, err = RequestFiltersArgs(r, config)
+	test.AssertError(t, err)
+	// should not be OK as "blockedResourceTypes"
+	// is not a JSON array.
+	r.WithArg(BlockedResourceTypesArgKey, "font")
+	_, err = RequestFiltersArgs(r, config)
+	test.AssertError(t, err)
+	r.WithArg(BlockedResourceTypesArgKey, "")
+	// should not be OK as "blockPrivateNetworks"
+	// is not a boolean.
+	r.WithArg(BlockPrivateNetworksArgKey, "foo")
+	_, err = RequestFiltersArgs(r, config)
+	test.AssertError(t, err)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestSplitArgs(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	opts := printer.DefaultSplitPrinterOptions(config)
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// should not be OK as "pageRanges"
+	// is required by default.
+	mode, pageRanges, err := SplitArgs(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, opts.Mode, mode)
+	assert.Nil(t, pageRanges)
+	// arguments exist.
+	r.WithArg(PageRangesArgKey, "1-2,4")
+	mode, pageRanges, err = SplitArgs(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, printer.RangesSplitMode, mode)
+	assert.Equal(t, []xpdf.PageRange{{From: 1, To: 2}, {From: 4, To: 4}}, pageRanges)
+	// "pageRanges" is ignored if the
+	// split mode is "pages".
+	r.WithArg(SplitModeArgKey, printer.PagesSplitMode)
+	mode, pageRanges, err = SplitArgs(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, printer.PagesSplitMode, mode)
+	assert.Nil(t, pageRanges)
+	// should not be OK as "splitMode"
+	// is not a split mode.
+	r.WithArg(SplitModeArgKey, "foo")
+	_, _, err = SplitArgs(r, config)
+	test.AssertError(t, err)
+	// should not be OK as "pageRanges"
+	// is not valid.
+	r.WithArg(SplitModeArgKey, printer.RangesSplitMode)
+	r.WithArg(PageRangesArgKey, "foo")
+	_, _, err = SplitArgs(r, config)
+	test.AssertError(t, err)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestOverlayArgs(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// arguments do not exist.
+	overlays, err := OverlayArgs(r, config)
+	assert.Nil(t, err)
+	assert.Empty(t, overlays)
+	// arguments exist.
+	r.WithArg(WatermarkTextArgKey, "DRAFT")
+	r.WithArg(WatermarkRotationArgKey, "45")
+	r.WithArg(WatermarkPageRangesArgKey, "1-2")
+	fpath := test.MergeFpaths(t)[0]
+	f, err := os.Open(fpath)
+	assert.Nil(t, err)
+	defer f.Close() // nolint: errcheck
+	err = r.WithFile(StampFilename, f)
+	assert.Nil(t, err)
+	r.WithArg(StampOpacityArgKey, "1")
+	overlays, err = OverlayArgs(r, config)
+	assert.Nil(t, err)
+	assert.Len(t, overlays, 2)
+	assert.Equal(t, "DRAFT", overlays[0].Text)
+	assert.Equal(t, 45.0, overlays[0].Rotation)
+	assert.Equal(t, 0.5, overlays[0].Opacity)
+	assert.Equal(t, []xpdf.PageRange{{From: 1, To: 2}}, overlays[0].PageRanges)
+	assert.False(t, overlays[0].OnTop)
+	assert.NotEmpty(t, overlays[1].Fpath)
+	assert.Equal(t, 1.0, overlays[1].Opacity)
+	assert.True(t, overlays[1].OnTop)
+	// should not be OK as "watermarkOpacity"
+	// is > 1.
+	r.WithArg(WatermarkOpacityArgKey, "2")
+	_, err = OverlayArgs(r, config)
+	test.AssertError(t, err)
+	r.WithArg(WatermarkOpacityArgKey, "")
+	// should not be OK as "watermarkPageRanges"
+	// is not valid.
+	r.WithArg(WatermarkPageRangesArgKey, "foo")
+	_, err = OverlayArgs(r, config)
+	test.AssertError(t, err)
+	r.WithArg(WatermarkPageRangesArgKey, "")
+	// should not be OK as there are both
+	// a stamp file and a stamp text.
+	r.WithArg(StampTextArgKey, "CONFIDENTIAL")
+	_, err = OverlayArgs(r, config)
+	test.AssertError(t, err)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestEncryptionArgs(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// arguments do not exist.
+	_, ok, err := EncryptionArgs(r, config)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	// should not be OK as restricting
+	// permissions requires an owner password.
+	r.WithArg(AllowPrintingArgKey, "false")
+	_, _, err = EncryptionArgs(r, config)
+	test.AssertError(t, err)
+	// arguments exist.
+	r.WithArg(UserPasswordArgKey, "foo")
+	r.WithArg(OwnerPasswordArgKey, "bar")
+	encryption, ok, err := EncryptionArgs(r, config)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	expected := xpdf.Encryption{
+		UserPassword:  "foo",
+		OwnerPassword: "bar",
+		AllowPrinting: false,
+		AllowCopying:  true,
+	}
+	assert.Equal(t, expected, encryption)
+	// should not be OK as "allowCopying"
+	// is not a boolean.
+	r.WithArg(AllowCopyingArgKey, "foo")
+	_, _, err = EncryptionArgs(r, config)
+	test.AssertError(t, err)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestPDFFormatArg(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	os.Setenv(conf.DisableUnoconvEnvVar, "0")
+	config, err := conf.FromEnv()
+	assert.Nil(t, err)
+	os.Unsetenv(conf.DisableUnoconvEnvVar)
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	v, err := PDFFormatArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, "", v)
+	// argument exist.
+	r.WithArg(PDFFormatArgKey, xpdf.PDFA2b)
+	v, err = PDFFormatArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, xpdf.PDFA2b, v)
+	// should not be OK as unoconv
+	// is disabled.
+	_, err = PDFFormatArg(r, conf.DefaultConfig())
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// should not be OK as argument
+	// value is not a PDF format.
+	r.WithArg(PDFFormatArgKey, "PDF/A-1a")
+	_, err = PDFFormatArg(r, config)
+	test.AssertError(t, err)
+	// should default to PDF/A-3b as there
+	// is a Factur-X invoice.
+	f, err := os.Open(test.AttachmentFpaths(t)[0])
+	assert.Nil(t, err)
+	defer f.Close() // nolint: errcheck
+	err = r.WithFile(FacturXFilename, f)
+	assert.Nil(t, err)
+	r.WithArg(PDFFormatArgKey, "")
+	v, err = PDFFormatArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, xpdf.PDFA3b, v)
+	// should not be OK as a Factur-X
+	// invoice requires PDF/A-3b.
+	r.WithArg(PDFFormatArgKey, xpdf.PDFA2b)
+	_, err = PDFFormatArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestAttachmentArgs(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// arguments do not exist.
+	attachments, err := AttachmentArgs(r, config)
+	assert.Nil(t, err)
+	assert.Empty(t, attachments)
+	// arguments exist.
+	fpaths := test.AttachmentFpaths(t)
+	for _, fpath := range fpaths {
+		f, err := os.Open(fpath)
+		assert.Nil(t, err)
+		defer f.Close() // nolint: errcheck
+		err = r.WithFile(filepath.Base(fpath), f)
+		assert.Nil(t, err)
+	}
+	r.WithArg(AttachmentsArgKey, `["notes.txt"]`)
+	r.WithArg(AttachmentsRelationshipArgKey, xpdf.SupplementRelationship)
+	attachments, err = AttachmentArgs(r, config)
+	assert.Nil(t, err)
+	assert.Len(t, attachments, 2)
+	assert.Equal(t, FacturXFilename, filepath.Base(attachments[0].Fpath))
+	assert.Equal(t, "notes.txt", filepath.Base(attachments[1].Fpath))
+	assert.Equal(t, xpdf.SupplementRelationship, attachments[1].Relationship)
+	// should not be OK as "attachmentsRelationship"
+	// is not an AFRelationship.
+	r.WithArg(AttachmentsRelationshipArgKey, "foo")
+	_, err = AttachmentArgs(r, config)
+	test.AssertError(t, err)
+	r.WithArg(AttachmentsRelationshipArgKey, "")
+	// should not be OK as "attachments"
+	// is not a JSON array.
+	r.WithArg(AttachmentsArgKey, "notes.txt")
+	_, err = AttachmentArgs(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestMetadataArgs(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// arguments do not exist.
+	metadata, err := MetadataArgs(r, config)
+	assert.Nil(t, err)
+	assert.True(t, metadata.IsEmpty())
+	// arguments exist.
+	r.WithArg(TitleArgKey, "Bible")
+	r.WithArg(AuthorArgKey, "Johannes")
+	r.WithArg(SubjectArgKey, "Printing")
+	r.WithArg(KeywordsArgKey, "press, movable type")
+	metadata, err = MetadataArgs(r, config)
+	assert.Nil(t, err)
+	expected := xpdf.Metadata{
+		Title:    "Bible",
+		Author:   "Johannes",
+		Subject:  "Printing",
+		Keywords: "press, movable type",
+	}
+	assert.Equal(t, expected, metadata)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestBookmarkTitlesArg(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	for _, fpath := range test.MergeFpaths(t) {
+		f, err := os.Open(fpath)
+		assert.Nil(t, err)
+		defer f.Close() // nolint: errcheck
+		err = r.WithFile(filepath.Base(fpath), f)
+		assert.Nil(t, err)
+	}
+	f, err := os.Open(test.MergeFpaths(t)[0])
+	assert.Nil(t, err)
+	defer f.Close() // nolint: errcheck
+	err = r.WithFile("café.pdf", f)
+	assert.Nil(t, err)
+	fpaths, err := r.Fpaths(".pdf")
+	assert.Nil(t, err)
+	// arguments do not exist.
+	titles, err := BookmarkTitlesArg(r, config, fpaths)
+	assert.Nil(t, err)
+	assert.Nil(t, titles)
+	// should title the bookmarks after
+	// the filenames.
+	r.WithArg(BookmarksArgKey, "true")
+	titles, err = BookmarkTitlesArg(r, config, fpaths)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"gotenberg", "gotenberg_bis", "café"}, titles)
+	// should use the labels, which also
+	// enable the bookmarks.
+	r.WithArg(BookmarksArgKey, "")
+	r.WithArg(BookmarkLabelsArgKey, `{"gotenberg_bis.pdf":"Appendix"}`)
+	titles, err = BookmarkTitlesArg(r, config, fpaths)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"gotenberg", "Appendix", "café"}, titles)
+	// should match the labels against the
+	// original filenames.
+	r.WithArg(BookmarkLabelsArgKey, `{"café.pdf":"Café"}`)
+	titles, err = BookmarkTitlesArg(r, config, fpaths)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"gotenberg", "gotenberg_bis", "Café"}, titles)
+	// should not be OK as a file from the
+	// labels argument does not exist.
+	r.WithArg(BookmarkLabelsArgKey, `{"foo.pdf":"Foo"}`)
+	_, err = BookmarkTitlesArg(r, config, fpaths)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// should not be OK as the labels
+	// argument is not a JSON object.
+	r.WithArg(BookmarkLabelsArgKey, "Appendix")
+	_, err = BookmarkTitlesArg(r, config, fpaths)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestResourceFailureArgs(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// arguments do not exist.
+	policy, err := ResourceFailurePolicyArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, printer.AllResourceFailurePolicy, policy)
+	statusCodes, err := IgnoredStatusCodesArg(r, config)
+	assert.Nil(t, err)
+	assert.Nil(t, statusCodes)
+	ignored, fatal, err := ResourceURLPatternsArgs(r, config)
+	assert.Nil(t, err)
+	assert.Nil(t, ignored)
+	assert.Nil(t, fatal)
+	// arguments exist.
+	r.WithArg(ResourceFailurePolicyArgKey, printer.MainDocumentResourceFailurePolicy)
+	r.WithArg(IgnoredStatusCodesArgKey, "[404,410]")
+	r.WithArg(IgnoredResourceURLsArgKey, `["*/favicon.ico"]`)
+	r.WithArg(FatalResourceURLsArgKey, `["*.css","*.woff"]`)
+	policy, err = ResourceFailurePolicyArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, printer.MainDocumentResourceFailurePolicy, policy)
+	statusCodes, err = IgnoredStatusCodesArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{404, 410}, statusCodes)
+	ignored, fatal, err = ResourceURLPatternsArgs(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"*/favicon.ico"}, ignored)
+	assert.Equal(t, []string{"*.css", "*.woff"}, fatal)
+	// should not be OK as "resourceFailurePolicy"
+	// is not a policy.
+	r.WithArg(ResourceFailurePolicyArgKey, "foo")
+	_, err = ResourceFailurePolicyArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// should not be OK as "ignoredStatusCodes"
+	// has a successful status code.
+	r.WithArg(IgnoredStatusCodesArgKey, "[200]")
+	_, err = IgnoredStatusCodesArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// should not be OK as "ignoredStatusCodes"
+	// is not a JSON array.
+	r.WithArg(IgnoredStatusCodesArgKey, "404")
+	_, err = IgnoredStatusCodesArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// should not be OK as "fatalResourceURLs"
+	// has an empty pattern.
+	r.WithArg(FatalResourceURLsArgKey, `[""]`)
+	_, _, err = ResourceURLPatternsArgs(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestJavaScriptErrorPolicyArg(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	policy, err := JavaScriptErrorPolicyArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, printer.FailOnExceptionJavaScriptErrorPolicy, policy)
+	// argument exists.
+	r.WithArg(JavaScriptErrorPolicyArgKey, printer.FailOnConsoleErrorJavaScriptErrorPolicy)
+	policy, err = JavaScriptErrorPolicyArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, printer.FailOnConsoleErrorJavaScriptErrorPolicy, policy)
+	// should not be OK as "javascriptErrorPolicy"
+	// is not a policy.
+	r.WithArg(JavaScriptErrorPolicyArgKey, "foo")
+	_, err = JavaScriptErrorPolicyArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestMediaArgs(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// arguments do not exist.
+	mediaType, colorScheme, err := MediaArgs(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, "", mediaType)
+	assert.Equal(t, "", colorScheme)
+	// arguments exist.
+	r.WithArg(EmulatedMediaTypeArgKey, printer.ScreenMediaType)
+	r.WithArg(PreferredColorSchemeArgKey, printer.DarkColorScheme)
+	mediaType, colorScheme, err = MediaArgs(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, printer.ScreenMediaType, mediaType)
+	assert.Equal(t, printer.DarkColorScheme, colorScheme)
+	// should not be OK as "emulatedMediaType"
+	// is not a media type.
+	r.WithArg(EmulatedMediaTypeArgKey, "tv")
+	_, _, err = MediaArgs(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	r.WithArg(EmulatedMediaTypeArgKey, "")
+	// should not be OK as "preferredColorScheme"
+	// is not a color scheme.
+	r.WithArg(PreferredColorSchemeArgKey, "sepia")
+	_, _, err = MediaArgs(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
@@ -8,8 +8,34 @@ import (
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/printer"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xpdf"
 )
 
+const (
+	// WatermarkFilename is the filename of
+	// the PDF file displayed in the background
+	// of the resulting PDF file.
+	WatermarkFilename string = "watermark.pdf"
+	// StampFilename is the filename of
+	// the PDF file displayed in the foreground
+	// of the resulting PDF file.
+	StampFilename string = "stamp.pdf"
+	// FacturXFilename is the filename of
+	// the XML invoice embedded into the
+	// resulting PDF file.
+	FacturXFilename string = xpdf.FacturXFilename
+)
+
+/*
+isReservedFilename returns true if the
+given filename is not a file to convert.
+*/
+func isReservedFilename(filename string) bool {
+	return filename == WatermarkFilename ||
+		filename == StampFilename ||
+		filename == FacturXFilename
+}
+
 // file represents a file within the resource.
 type file struct {
 	fpath string
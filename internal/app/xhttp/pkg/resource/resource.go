@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -29,6 +30,9 @@ type Resource struct {
 	customHeaders map[string]string
 	args          map[ArgKey]string
 	files         map[string]file
+	// filenames keeps the files in
+	// the order they have been added.
+	filenames []string
 }
 
 // New creates a Resource where its files will
@@ -94,6 +98,10 @@ func (r *Resource) WithCustomHTTPHeader(key string, value string) {
 func (r *Resource) WithArg(key ArgKey, value string) {
 	const op string = "resource.Resource.WithArg"
 	r.args[key] = value
+	if isSecretArgKey(key) && value != "" {
+		// never log passwords nor cookies.
+		value = "***"
+	}
 	r.logger.DebugOpf(op, "added '%s' with value '%s' to resource args", key, value)
 }
 
@@ -111,6 +119,9 @@ func (r *Resource) WithFile(filename string, in io.Reader) error {
 		if err := file.write(in); err != nil {
 			return err
 		}
+		if _, ok := r.files[filename]; !ok {
+			r.filenames = append(r.filenames, filename)
+		}
 		r.files[filename] = file
 		r.logger.DebugOpf(op, "resource file '%s' created", filename)
 		return nil
@@ -215,15 +226,39 @@ func (r Resource) Fpath(filename string) (string, error) {
 Fpaths returns the paths of the files
 having one of the given file extensions.
 
+The paths follow the order of the "order"
+argument (if any), then the order in which
+the files have been added.
+
+The "watermark.pdf", "stamp.pdf" and
+"factur-x.xml" files, and the files listed
+in the "attachments" argument, are never
+returned.
+
 It should found at least one path.
 */
 func (r Resource) Fpaths(exts ...string) ([]string, error) {
 	const op string = "resource.Resource.Fpaths"
+	filenames, err := r.orderedFilenames()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	attachments, err := r.attachmentFilenames()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	attached := make(map[string]bool)
+	for _, filename := range attachments {
+		attached[filename] = true
+	}
 	var fpaths []string
-	for filename, file := range r.files {
+	for _, filename := range filenames {
+		if isReservedFilename(filename) || attached[filename] {
+			continue
+		}
 		for _, ext := range exts {
 			if filepath.Ext(filename) == ext {
-				fpaths = append(fpaths, file.fpath)
+				fpaths = append(fpaths, r.files[filename].fpath)
 			}
 		}
 	}
@@ -237,6 +272,90 @@ func (r Resource) Fpaths(exts ...string) ([]string, error) {
 	return fpaths, nil
 }
 
+/*
+orderedFilenames returns the filenames listed
+in the "order" argument first, then the other
+filenames in the order they have been added.
+
+The "order" argument is a JSON array of
+filenames, e.g. ["cover.pdf","body.pdf"].
+*/
+func (r Resource) orderedFilenames() ([]string, error) {
+	const op string = "resource.Resource.orderedFilenames"
+	if !r.HasArg(OrderArgKey) {
+		return r.filenames, nil
+	}
+	var order []string
+	if err := json.Unmarshal([]byte(r.args[OrderArgKey]), &order); err != nil {
+		return nil, xerror.Invalid(
+			op,
+			fmt.Sprintf("'%s' should be a JSON array of filenames, e.g. [\"cover.pdf\",\"body.pdf\"]", OrderArgKey),
+			err,
+		)
+	}
+	listed := make(map[string]bool)
+	filenames := make([]string, 0, len(r.filenames))
+	for _, filename := range order {
+		if _, ok := r.files[filename]; !ok {
+			return nil, xerror.Invalid(
+				op,
+				fmt.Sprintf("resource file '%s' from '%s' does not exist", filename, OrderArgKey),
+				nil,
+			)
+		}
+		if listed[filename] {
+			continue
+		}
+		listed[filename] = true
+		filenames = append(filenames, filename)
+	}
+	for _, filename := range r.filenames {
+		if !listed[filename] {
+			filenames = append(filenames, filename)
+		}
+	}
+	return filenames, nil
+}
+
+/*
+attachmentFilenames returns the filenames
+listed in the "attachments" argument.
+
+The "attachments" argument is a JSON array of
+filenames, e.g. ["invoice.xml","terms.pdf"].
+*/
+func (r Resource) attachmentFilenames() ([]string, error) {
+	const op string = "resource.Resource.attachmentFilenames"
+	if !r.HasArg(AttachmentsArgKey) {
+		return nil, nil
+	}
+	var attachments []string
+	if err := json.Unmarshal([]byte(r.args[AttachmentsArgKey]), &attachments); err != nil {
+		return nil, xerror.Invalid(
+			op,
+			fmt.Sprintf("'%s' should be a JSON array of filenames, e.g. [\"invoice.xml\",\"terms.pdf\"]", AttachmentsArgKey),
+			err,
+		)
+	}
+	listed := make(map[string]bool)
+	filenames := make([]string, 0, len(attachments))
+	for _, filename := range attachments {
+		if _, ok := r.files[filename]; !ok {
+			return nil, xerror.Invalid(
+				op,
+				fmt.Sprintf("resource file '%s' from '%s' does not exist", filename, AttachmentsArgKey),
+				nil,
+			)
+		}
+		if listed[filename] {
+			continue
+		}
+		listed[filename] = true
+		filenames = append(filenames, filename)
+	}
+	return filenames, nil
+}
+
 /*
 Fcontent returns the string content of the
 given filename.
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xassert"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
 	"github.com/thecodingmachine/gotenberg/test"
 )
 
@@ -234,6 +235,70 @@ func TestFpaths(t *testing.T) {
 	// does not exist.
 	_, err = r.Fpaths(".html")
 	test.AssertError(t, err)
+	// should keep the order in which
+	// files have been added.
+	_, err = f.Seek(0, 0)
+	assert.Nil(t, err)
+	err = r.WithFile("bar.pdf", f)
+	assert.Nil(t, err)
+	expected = []string{
+		fmt.Sprintf("%s/%s", absDirPath, "foo.pdf"),
+		fmt.Sprintf("%s/%s", absDirPath, "bar.pdf"),
+	}
+	fpaths, err = r.Fpaths(".pdf")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, fpaths)
+	// should follow the order argument.
+	r.WithArg(OrderArgKey, `["bar.pdf"]`)
+	expected = []string{
+		fmt.Sprintf("%s/%s", absDirPath, "bar.pdf"),
+		fmt.Sprintf("%s/%s", absDirPath, "foo.pdf"),
+	}
+	fpaths, err = r.Fpaths(".pdf")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, fpaths)
+	// should not be OK as the order
+	// argument is not a JSON array.
+	r.WithArg(OrderArgKey, "bar.pdf")
+	_, err = r.Fpaths(".pdf")
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// should not be OK as a file from
+	// the order argument does not exist.
+	r.WithArg(OrderArgKey, `["baz.pdf"]`)
+	_, err = r.Fpaths(".pdf")
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// should not return the watermark
+	// and stamp files.
+	r.WithArg(OrderArgKey, "")
+	for _, filename := range []string{WatermarkFilename, StampFilename} {
+		_, err = f.Seek(0, 0)
+		assert.Nil(t, err)
+		err = r.WithFile(filename, f)
+		assert.Nil(t, err)
+	}
+	expected = []string{
+		fmt.Sprintf("%s/%s", absDirPath, "foo.pdf"),
+		fmt.Sprintf("%s/%s", absDirPath, "bar.pdf"),
+	}
+	fpaths, err = r.Fpaths(".pdf")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, fpaths)
+	// should not return the attachments.
+	r.WithArg(AttachmentsArgKey, `["bar.pdf"]`)
+	expected = []string{
+		fmt.Sprintf("%s/%s", absDirPath, "foo.pdf"),
+	}
+	fpaths, err = r.Fpaths(".pdf")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, fpaths)
+	// should not be OK as a file from the
+	// attachments argument does not exist.
+	r.WithArg(AttachmentsArgKey, `["baz.pdf"]`)
+	_, err = r.Fpaths(".pdf")
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
 	// finally...
 	err = r.Close()
 	assert.Nil(t, err)
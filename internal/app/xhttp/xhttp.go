@@ -3,7 +3,11 @@ package xhttp
 import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/context"
+	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/job"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/admission"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
 )
 
 // New returns a custom echo.Echo.
@@ -25,12 +29,36 @@ func New(config conf.Config) *echo.Echo {
 		}))
 	}
 
-	srv.Use(contextMiddleware(config))
+	// the conversion and the post-processing may
+	// both wait for a slot and until their timeout.
+	jobTimeout := 2 * (config.MaximumWaitTimeout() + config.QueueWaitTimeout())
+	jobs := job.NewStore(
+		xlog.New(config.LogLevel(), "jobs"),
+		config.JobResultTTL(),
+		jobTimeout,
+		config.MaximumPendingJobs(),
+	)
+	admissions := context.Admissions{
+		GoogleChrome: admission.New(
+			config.GoogleChromeMaxConnections()*config.GoogleChromeInstances(),
+			config.MaximumQueueLength(),
+			config.QueueWaitTimeout(),
+		),
+		Processes: admission.New(
+			config.MaximumConcurrentProcesses(),
+			config.MaximumQueueLength(),
+			config.QueueWaitTimeout(),
+		),
+	}
+	srv.Use(contextMiddleware(config, jobs, admissions))
 	srv.Use(loggerMiddleware(config))
 	srv.Use(cleanupMiddleware())
 	srv.Use(errorMiddleware())
 	srv.GET(pingEndpoint(config), pingHandler)
+	srv.GET(jobEndpoint(config), jobHandler)
+	srv.GET(jobResultEndpoint(config), jobResultHandler)
 	// srv.POST(mergeEndpoint(config), mergeHandler)
+	srv.POST(splitEndpoint(config), splitHandler)
 	if config.DisableGoogleChrome() && config.DisableUnoconv() {
 		return srv
 	}
@@ -38,6 +66,8 @@ func New(config conf.Config) *echo.Echo {
 		srv.POST(htmlEndpoint(config), htmlHandler)
 		srv.POST(urlEndpoint(config), urlHandler)
 		srv.POST(markdownEndpoint(config), markdownHandler)
+		srv.POST(screenshotHTMLEndpoint(config), screenshotHTMLHandler)
+		srv.POST(screenshotURLEndpoint(config), screenshotURLHandler)
 	}
 	if !config.DisableUnoconv() {
 		// srv.POST(officeEndpoint(config), officeHandler)
@@ -0,0 +1,175 @@
+package admission
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xtime"
+)
+
+/*
+Controller is a semaphore which admits a
+maximum number of concurrent conversions.
+
+Other conversions wait in a bounded FIFO queue.
+*/
+type Controller struct {
+	mu             *sync.Mutex
+	capacity       int64
+	maxQueueLength int64
+	queueTimeout   time.Duration
+	active         int64
+	queue          *list.List
+}
+
+/*
+New returns a Controller which admits given
+number of concurrent conversions.
+
+A conversion waits in the queue at most
+given seconds; 0 means until its
+context.Context is done.
+*/
+func New(capacity, maxQueueLength int64, queueTimeout float64) *Controller {
+	return &Controller{
+		mu:             &sync.Mutex{},
+		capacity:       capacity,
+		maxQueueLength: maxQueueLength,
+		queueTimeout:   xtime.Duration(queueTimeout),
+		queue:          list.New(),
+	}
+}
+
+/*
+Acquire admits a conversion, waiting in the
+queue if there is no free slot.
+
+It returns an error with xerror.BusyCode if
+the queue is full and an error with
+xerror.UnavailableCode if the conversion has
+waited too long.
+*/
+func (c *Controller) Acquire(ctx context.Context) error {
+	const op string = "admission.Controller.Acquire"
+	c.mu.Lock()
+	if c.active < c.capacity && c.queue.Len() == 0 {
+		c.active++
+		c.mu.Unlock()
+		return nil
+	}
+	if int64(c.queue.Len()) >= c.maxQueueLength {
+		c.mu.Unlock()
+		return xerror.Busy(
+			op,
+			fmt.Sprintf("too many conversions: the queue is full (%d)", c.maxQueueLength),
+			nil,
+		)
+	}
+	ready := make(chan struct{})
+	elem := c.queue.PushBack(ready)
+	c.mu.Unlock()
+	var timeout <-chan time.Time
+	if c.queueTimeout > 0 {
+		timer := time.NewTimer(c.queueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-ready:
+		return nil
+	case <-timeout:
+		if c.leave(elem, ready) {
+			return nil
+		}
+		return xerror.Unavailable(
+			op,
+			fmt.Sprintf("no conversion slot available after '%v'", c.queueTimeout),
+			nil,
+		)
+	case <-ctx.Done():
+		if c.leave(elem, ready) {
+			return nil
+		}
+		return xerror.New(op, ctx.Err())
+	}
+}
+
+/*
+TryAcquire admits a conversion only if there
+is a free slot and nobody is waiting.
+
+Otherwise, it returns an error with
+xerror.BusyCode.
+*/
+func (c *Controller) TryAcquire() error {
+	const op string = "admission.Controller.TryAcquire"
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.active < c.capacity && c.queue.Len() == 0 {
+		c.active++
+		return nil
+	}
+	return xerror.Busy(
+		op,
+		"too many conversions: no conversion slot available",
+		nil,
+	)
+}
+
+/*
+Release frees the slot of a conversion. The
+slot is directly handed to the oldest waiting
+conversion, if any.
+*/
+func (c *Controller) Release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if front := c.queue.Front(); front != nil {
+		c.queue.Remove(front)
+		close(front.Value.(chan struct{}))
+		return
+	}
+	c.active--
+}
+
+// String implements fmt.Stringer.
+func (c *Controller) String() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return fmt.Sprintf(
+		"{capacity:%d active:%d queued:%d maxQueueLength:%d queueTimeout:%v}",
+		c.capacity,
+		c.active,
+		c.queue.Len(),
+		c.maxQueueLength,
+		c.queueTimeout,
+	)
+}
+
+/*
+leave removes a waiting conversion from the queue.
+
+It returns true if a slot has been handed to the
+conversion in the meantime: the conversion
+is then admitted.
+*/
+func (c *Controller) leave(elem *list.Element, ready chan struct{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	select {
+	case <-ready:
+		return true
+	default:
+	}
+	c.queue.Remove(elem)
+	return false
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = fmt.Stringer(new(Controller))
+)
@@ -0,0 +1,75 @@
+package admission
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestAcquire(t *testing.T) {
+	c := New(1, 1, 0.1)
+	ctx := context.Background()
+	// should be admitted.
+	err := c.Acquire(ctx)
+	assert.Nil(t, err)
+	// should not be OK as the conversion
+	// waited too long in the queue.
+	err = c.Acquire(ctx)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.UnavailableCode, xerror.Code(err))
+	// should be admitted once the slot
+	// is released.
+	admitted := make(chan error, 1)
+	c = New(1, 1, 0)
+	err = c.Acquire(ctx)
+	assert.Nil(t, err)
+	go func() {
+		admitted <- c.Acquire(ctx)
+	}()
+	// wait for the conversion to be queued.
+	for c.queueLength() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	// should not be OK as the queue is full.
+	err = c.Acquire(ctx)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.BusyCode, xerror.Code(err))
+	c.Release()
+	assert.Nil(t, <-admitted)
+	c.Release()
+	assert.Equal(t, int64(0), c.active)
+	// should not be OK as the context.Context
+	// is done.
+	err = c.Acquire(ctx)
+	assert.Nil(t, err)
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	err = c.Acquire(cancelCtx)
+	test.AssertError(t, err)
+	assert.Equal(t, 0, c.queueLength())
+}
+
+func TestTryAcquire(t *testing.T) {
+	c := New(1, 1, 0)
+	// should be admitted.
+	err := c.TryAcquire()
+	assert.Nil(t, err)
+	// should not be OK as there is
+	// no free slot.
+	err = c.TryAcquire()
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.BusyCode, xerror.Code(err))
+	c.Release()
+	err = c.TryAcquire()
+	assert.Nil(t, err)
+}
+
+func (c *Controller) queueLength() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queue.Len()
+}
@@ -0,0 +1,9 @@
+/*
+Package admission helps limiting the number
+of concurrent conversions thanks to a semaphore
+with a bounded FIFO wait queue.
+
+All functions return our standard xerror.Error
+in case of error.
+*/
+package admission
@@ -2,48 +2,51 @@ package chrome
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/mafredri/cdp/devtool"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xexec"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xtime"
 )
 
-// Start starts Google Chrome headless in background.
-func Start(logger xlog.Logger, ignoreCertificateErrors bool) error {
-	const op string = "chrome.Start"
-	logger.DebugOp(op, "starting new Google Chrome headless process on port 9222...")
-	resolver := func() error {
-		cmd, err := cmd(logger, ignoreCertificateErrors)
-		if err != nil {
-			return err
-		}
-		// we try to start the process.
-		xexec.LogBeforeExecute(logger, cmd)
-		if err := cmd.Start(); err != nil {
-			return err
-		}
-		// if the process failed to start correctly,
-		// we have to restart it.
-		isViable, _ := IsViable(logger)
-		if !isViable {
-			return restart(logger, cmd.Process, ignoreCertificateErrors)
-		}
-		return nil
-	}
-	if err := resolver(); err != nil {
-		return xerror.New(op, err)
+/*
+DefaultPort is the remote debugging port
+of the first Google Chrome headless process.
+
+The other processes use the following ports.
+*/
+const DefaultPort int = 9222
+
+// Options helps customizing the
+// Google Chrome processes.
+type Options struct {
+	Instances               int64
+	IgnoreCertificateErrors bool
+	RecycleAfter            int64
+	MaxMemory               int64
+}
+
+// DefaultOptions returns the default
+// Google Chrome processes options.
+func DefaultOptions(config conf.Config) Options {
+	return Options{
+		Instances:               config.GoogleChromeInstances(),
+		IgnoreCertificateErrors: config.GoogleChromeIgnoreCertificateErrors(),
+		RecycleAfter:            config.GoogleChromeRecycleAfter(),
+		MaxMemory:               config.GoogleChromeMaxMemory(),
 	}
-	return nil
 }
 
-func cmd(logger xlog.Logger, ignoreCertificateErrors bool) (*exec.Cmd, error) {
+func cmd(logger xlog.Logger, port int, ignoreCertificateErrors bool) (*exec.Cmd, error) {
 	const op string = "chrome.cmd"
 	binary := "google-chrome-stable"
 	args := []string{
@@ -54,7 +57,9 @@ func cmd(logger xlog.Logger, ignoreCertificateErrors bool) (*exec.Cmd, error) {
 		// See https://github.com/puppeteer/puppeteer/issues/661
 		// and https://github.com/puppeteer/puppeteer/issues/2410.
 		"--font-render-hinting=none",
-		"--remote-debugging-port=9222",
+		fmt.Sprintf("--remote-debugging-port=%d", port),
+		// each process needs its own profile.
+		fmt.Sprintf("--user-data-dir=%s", userDataDir(port)),
 		"--disable-gpu",
 		"--disable-translate",
 		"--disable-extensions",
@@ -80,9 +85,13 @@ func cmd(logger xlog.Logger, ignoreCertificateErrors bool) (*exec.Cmd, error) {
 	return cmd, nil
 }
 
-func kill(logger xlog.Logger, proc *os.Process) error {
+func userDataDir(port int) string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("gotenberg-google-chrome-%d", port))
+}
+
+func kill(logger xlog.Logger, proc *os.Process, port int) error {
 	const op string = "chrome.kill"
-	logger.DebugOp(op, "killing Google Chrome headless process using port 9222...")
+	logger.DebugOpf(op, "killing Google Chrome headless process using port %d...", port)
 	resolver := func() error {
 		err := syscall.Kill(-proc.Pid, syscall.SIGKILL)
 		if err == nil {
@@ -99,76 +108,57 @@ func kill(logger xlog.Logger, proc *os.Process) error {
 	return nil
 }
 
-func restart(logger xlog.Logger, proc *os.Process, ignoreCertificateErrors bool) error {
-	const op string = "chrome.restart"
-	logger.DebugOp(op, "restarting Google Chrome headless process using port 9222...")
-	resolver := func() error {
-		// kill the existing process first.
-		if err := kill(logger, proc); err != nil {
-			return err
-		}
-		cmd, err := cmd(logger, ignoreCertificateErrors)
-		if err != nil {
-			return err
-		}
-		// we try to restart the process.
-		xexec.LogBeforeExecute(logger, cmd)
-		if err := cmd.Start(); err != nil {
-			return err
-		}
-		// if the process failed to restart correctly,
-		// we have to restart it again.
-		isViable, _ := IsViable(logger)
-		if !isViable {
-			return restart(logger, cmd.Process, ignoreCertificateErrors)
-		}
-		return nil
+/*
+IsViable checks if Google Chrome is healthy,
+i.e. if at least one of its processes
+is viable.
+*/
+func IsViable(logger xlog.Logger) (bool, error) {
+	if p := currentPool(); p != nil {
+		return p.isViable()
 	}
-	if err := resolver(); err != nil {
-		return xerror.New(op, err)
+	return isViable(logger, DefaultPort)
+}
+
+func isViable(logger xlog.Logger, port int) (bool, error) {
+	const maxViabilityTests int = 20
+	result := false
+	var err error
+	for i := 0; i < maxViabilityTests && !result; i++ {
+		warmup(logger)
+		result, err = viable(logger, port)
 	}
-	return nil
+	return result, err
 }
 
-// IsViable checks if Google Chrome is healthy.
-func IsViable(logger xlog.Logger) (bool, error) {
+func viable(logger xlog.Logger, port int) (bool, error) {
 	const (
-		op                string = "chrome.IsViable"
-		maxViabilityTests int    = 20
+		op      string  = "chrome.viable"
+		timeout float64 = 5.0
 	)
-	viable := func() (bool, error) {
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-		endpoint := "http://localhost:9222"
-		logger.DebugOpf(
-			op,
-			"checking Google Chrome headless process viability via endpoint '%s/json/version'",
-			endpoint,
-		)
-		v, err := devtool.New(endpoint).Version(ctx)
-		if err != nil {
-			logger.DebugOpf(
-				op,
-				"Google Chrome headless is not viable as endpoint returned '%v'",
-				err.Error(),
-			)
-			return false, err
-		}
+	ctx, cancel := context.WithTimeout(context.Background(), xtime.Duration(timeout))
+	defer cancel()
+	endpoint := fmt.Sprintf("http://localhost:%d", port)
+	logger.DebugOpf(
+		op,
+		"checking Google Chrome headless process viability via endpoint '%s/json/version'",
+		endpoint,
+	)
+	v, err := devtool.New(endpoint).Version(ctx)
+	if err != nil {
 		logger.DebugOpf(
 			op,
-			"Google Chrome headless is viable as endpoint returned '%v'",
-			v,
+			"Google Chrome headless is not viable as endpoint returned '%v'",
+			err.Error(),
 		)
-		return true, nil
-	}
-	result := false
-	var err error
-
-	for i := 0; i < maxViabilityTests && !result; i++ {
-		warmup(logger)
-		result, err = viable()
+		return false, xerror.New(op, err)
 	}
-	return result, err
+	logger.DebugOpf(
+		op,
+		"Google Chrome headless is viable as endpoint returned '%v'",
+		v,
+	)
+	return true, nil
 }
 
 func warmup(logger xlog.Logger) {
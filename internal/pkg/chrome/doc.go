@@ -1,3 +1,6 @@
-// Package chrome helps starting
-// Google Chrome headless in background.
+/*
+Package chrome helps starting and supervising
+a pool of Google Chrome headless processes
+in background.
+*/
 package chrome
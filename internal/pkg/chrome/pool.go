@@ -0,0 +1,403 @@
+package chrome
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xexec"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xtime"
+)
+
+// Instance is a Google Chrome headless
+// process of the pool.
+type Instance struct {
+	port int
+	pool *pool
+	// the following fields are
+	// protected by the pool mutex.
+	proc        *os.Process
+	exited      chan struct{}
+	active      int64
+	conversions int64
+	draining    bool
+	restarting  bool
+}
+
+// Port returns the remote debugging
+// port of the process.
+func (i *Instance) Port() int {
+	return i.port
+}
+
+// Endpoint returns the DevTools HTTP
+// endpoint of the process.
+func (i *Instance) Endpoint() string {
+	return fmt.Sprintf("http://localhost:%d", i.port)
+}
+
+/*
+Release gives the process back to the pool
+once a conversion is done.
+
+If the process has reached the maximum number
+of conversions, it will be restarted as soon
+as its last conversion is released.
+*/
+func (i *Instance) Release() {
+	const op string = "chrome.Instance.Release"
+	if i.pool == nil {
+		return
+	}
+	p := i.pool
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	i.active--
+	i.conversions++
+	if p.opts.RecycleAfter > 0 && i.conversions >= p.opts.RecycleAfter && !i.draining {
+		p.logger.DebugOpf(
+			op,
+			"Google Chrome headless process using port %d reached %d conversions, recycling it...",
+			i.port,
+			i.conversions,
+		)
+		i.draining = true
+	}
+}
+
+type pool struct {
+	logger    xlog.Logger
+	opts      Options
+	mu        *sync.Mutex
+	instances []*Instance
+}
+
+// nolint: gochecknoglobals
+var (
+	started   *pool
+	startedMu sync.RWMutex
+)
+
+func currentPool() *pool {
+	startedMu.RLock()
+	defer startedMu.RUnlock()
+	return started
+}
+
+/*
+Start starts a pool of Google Chrome headless
+processes in background.
+
+A watchdog restarts the processes which have
+died, and recycles the processes which have
+reached the maximum number of conversions or
+the maximum memory.
+*/
+func Start(logger xlog.Logger, opts Options) error {
+	const op string = "chrome.Start"
+	resolver := func() error {
+		p := &pool{
+			logger: logger,
+			opts:   opts,
+			mu:     &sync.Mutex{},
+		}
+		for n := int64(0); n < opts.Instances; n++ {
+			i := &Instance{
+				port: DefaultPort + int(n),
+				pool: p,
+			}
+			if err := p.start(i); err != nil {
+				return err
+			}
+			p.instances = append(p.instances, i)
+		}
+		startedMu.Lock()
+		started = p
+		startedMu.Unlock()
+		go p.watchdog()
+		return nil
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+Acquire returns a viable Google Chrome headless
+process for a conversion. Caller should call
+Release once the conversion is done.
+
+It waits for a process until the context.Context
+is done.
+
+If no pool has been started (i.e. Google Chrome
+is managed by another program), it returns the
+process listening on DefaultPort.
+*/
+func Acquire(ctx context.Context) (*Instance, error) {
+	const (
+		op           string  = "chrome.Acquire"
+		pollInterval float64 = 0.1
+	)
+	p := currentPool()
+	if p == nil {
+		return &Instance{port: DefaultPort}, nil
+	}
+	for {
+		if i := p.pick(); i != nil {
+			return i, nil
+		}
+		p.logger.DebugOp(op, "no viable Google Chrome headless process, waiting...")
+		select {
+		case <-ctx.Done():
+			return nil, xerror.New(op, ctx.Err())
+		case <-time.After(xtime.Duration(pollInterval)):
+		}
+	}
+}
+
+// pick returns the available process with
+// the fewest active conversions, if any.
+func (p *pool) pick() *Instance {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var result *Instance
+	for _, i := range p.instances {
+		if i.draining || i.restarting {
+			continue
+		}
+		if result == nil || i.active < result.active {
+			result = i
+		}
+	}
+	if result != nil {
+		result.active++
+	}
+	return result
+}
+
+func (p *pool) isViable() (bool, error) {
+	const op string = "chrome.pool.isViable"
+	err := errors.New("no viable Google Chrome headless process")
+	for _, i := range p.instances {
+		p.mu.Lock()
+		restarting := i.restarting
+		p.mu.Unlock()
+		if restarting {
+			continue
+		}
+		var ok bool
+		ok, err = viable(p.logger, i.port)
+		if ok {
+			return true, nil
+		}
+	}
+	return false, xerror.New(op, err)
+}
+
+/*
+start starts the process of given Instance,
+and tries again if it is not viable, up to
+maxStartAttempts times.
+*/
+func (p *pool) start(i *Instance) error {
+	const (
+		op               string = "chrome.pool.start"
+		maxStartAttempts int    = 5
+	)
+	p.logger.DebugOpf(op, "starting new Google Chrome headless process on port %d...", i.port)
+	resolver := func() error {
+		for attempt := 1; ; attempt++ {
+			// a fresh profile for each process.
+			if err := os.RemoveAll(userDataDir(i.port)); err != nil {
+				return err
+			}
+			cmd, err := cmd(p.logger, i.port, p.opts.IgnoreCertificateErrors)
+			if err != nil {
+				return err
+			}
+			// we try to start the process.
+			xexec.LogBeforeExecute(p.logger, cmd)
+			if err := cmd.Start(); err != nil {
+				return err
+			}
+			exited := make(chan struct{})
+			go func() {
+				// also reaps the process.
+				_ = cmd.Wait()
+				close(exited)
+			}()
+			p.mu.Lock()
+			i.proc = cmd.Process
+			i.exited = exited
+			p.mu.Unlock()
+			isViable, err := isViable(p.logger, i.port)
+			if isViable {
+				return nil
+			}
+			// if the process failed to start correctly,
+			// we have to restart it.
+			if err := kill(p.logger, cmd.Process, i.port); err != nil {
+				return err
+			}
+			if attempt >= maxStartAttempts {
+				return fmt.Errorf(
+					"Google Chrome headless process on port %d is still not viable after %d attempts: %v",
+					i.port,
+					attempt,
+					err,
+				)
+			}
+			p.logger.DebugOpf(
+				op,
+				"Google Chrome headless process on port %d is not viable, starting it again (attempt %d/%d)...",
+				i.port,
+				attempt+1,
+				maxStartAttempts,
+			)
+		}
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+func (p *pool) watchdog() {
+	const interval float64 = 5.0
+	ticker := time.NewTicker(xtime.Duration(interval))
+	defer ticker.Stop()
+	for range ticker.C {
+		for _, i := range p.instances {
+			p.supervise(i)
+		}
+	}
+}
+
+// supervise decides if given process
+// should be drained or restarted.
+func (p *pool) supervise(i *Instance) {
+	const op string = "chrome.pool.supervise"
+	p.mu.Lock()
+	if i.restarting {
+		p.mu.Unlock()
+		return
+	}
+	exited := false
+	select {
+	case <-i.exited:
+		exited = true
+	default:
+	}
+	if exited || (i.draining && i.active == 0) {
+		i.restarting = true
+		p.mu.Unlock()
+		if exited {
+			p.logger.ErrorOpf(op, "Google Chrome headless process using port %d has died", i.port)
+		}
+		go p.restart(i)
+		return
+	}
+	draining := i.draining
+	pid := i.proc.Pid
+	p.mu.Unlock()
+	if draining {
+		return
+	}
+	if ok, _ := viable(p.logger, i.port); !ok {
+		p.drain(i, "it is not viable")
+		return
+	}
+	if p.opts.MaxMemory <= 0 {
+		return
+	}
+	memory, err := rss(pid)
+	if err != nil {
+		p.logger.ErrorOp(op, err)
+		return
+	}
+	if memory > p.opts.MaxMemory {
+		p.drain(i, fmt.Sprintf("its memory (%d bytes) exceeds %d bytes", memory, p.opts.MaxMemory))
+	}
+}
+
+// drain stops routing new conversions to
+// given process so that it may be restarted.
+func (p *pool) drain(i *Instance, reason string) {
+	const op string = "chrome.pool.drain"
+	p.logger.DebugOpf(op, "recycling Google Chrome headless process using port %d as %s...", i.port, reason)
+	p.mu.Lock()
+	i.draining = true
+	p.mu.Unlock()
+}
+
+func (p *pool) restart(i *Instance) {
+	const op string = "chrome.pool.restart"
+	p.logger.DebugOpf(op, "restarting Google Chrome headless process using port %d...", i.port)
+	p.mu.Lock()
+	proc := i.proc
+	p.mu.Unlock()
+	if err := kill(p.logger, proc, i.port); err != nil {
+		p.logger.ErrorOp(op, err)
+	}
+	err := p.start(i)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// on failure, the watchdog will try again
+	// as the previous process has exited.
+	i.restarting = false
+	if err != nil {
+		p.logger.ErrorOp(op, err)
+		return
+	}
+	i.conversions = 0
+	i.draining = false
+}
+
+// rss returns the resident set size (in bytes)
+// of all the processes of given process group.
+func rss(pgid int) (int64, error) {
+	const op string = "chrome.rss"
+	entries, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return 0, xerror.New(op, err)
+	}
+	var total int64
+	for _, entry := range entries {
+		if _, err := strconv.Atoi(entry.Name()); err != nil {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join("/proc", entry.Name(), "stat"))
+		if err != nil {
+			// the process has exited in the meantime.
+			continue
+		}
+		// see man 5 proc: the command name may contain
+		// spaces, so we only look after its closing parenthesis.
+		stat := string(b)
+		fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+		if len(fields) < 22 {
+			continue
+		}
+		group, err := strconv.Atoi(fields[2])
+		if err != nil || group != pgid {
+			continue
+		}
+		pages, err := strconv.ParseInt(fields[21], 10, 64)
+		if err != nil {
+			continue
+		}
+		total += pages * int64(os.Getpagesize())
+	}
+	return total, nil
+}
@@ -0,0 +1,57 @@
+package chrome
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestAcquire(t *testing.T) {
+	// should return the process listening on
+	// DefaultPort as no pool has been started.
+	i, err := Acquire(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultPort, i.Port())
+	assert.Equal(t, "http://localhost:9222", i.Endpoint())
+	i.Release()
+}
+
+func TestPick(t *testing.T) {
+	p := &pool{
+		logger: test.DebugLogger(),
+		opts:   Options{Instances: 2, RecycleAfter: 2},
+		mu:     &sync.Mutex{},
+	}
+	first := &Instance{port: DefaultPort, pool: p}
+	second := &Instance{port: DefaultPort + 1, pool: p}
+	p.instances = []*Instance{first, second}
+	// should route conversions to the process
+	// with the fewest active conversions.
+	assert.Equal(t, first, p.pick())
+	assert.Equal(t, second, p.pick())
+	first.Release()
+	assert.Equal(t, first, p.pick())
+	// should be drained as it has reached
+	// the maximum number of conversions.
+	first.Release()
+	assert.True(t, first.draining)
+	assert.Equal(t, second, p.pick())
+	// should not return any process as
+	// none is available.
+	second.restarting = true
+	assert.Nil(t, p.pick())
+}
+
+func TestRSS(t *testing.T) {
+	pgid, err := syscall.Getpgid(os.Getpid())
+	require.Nil(t, err)
+	memory, err := rss(pgid)
+	assert.Nil(t, err)
+	assert.True(t, memory > 0)
+}
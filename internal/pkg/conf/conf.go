@@ -1,6 +1,8 @@
 package conf
 
 import (
+	"runtime"
+
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xassert"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
@@ -52,6 +54,30 @@ const (
 	// GoogleChromeWaitForConnectionEnvVar contains the name
 	// of the environment variable "GOOGLE_CHROME_WAIT_FOR_CONNECTION".
 	GoogleChromeWaitForConnectionEnvVar string = "GOOGLE_CHROME_WAIT_FOR_CONNECTION"
+	// GoogleChromeInstancesEnvVar contains the name
+	// of the environment variable "GOOGLE_CHROME_INSTANCES".
+	GoogleChromeInstancesEnvVar string = "GOOGLE_CHROME_INSTANCES"
+	// GoogleChromeRecycleAfterEnvVar contains the name
+	// of the environment variable "GOOGLE_CHROME_RECYCLE_AFTER".
+	GoogleChromeRecycleAfterEnvVar string = "GOOGLE_CHROME_RECYCLE_AFTER"
+	// GoogleChromeMaxMemoryEnvVar contains the name
+	// of the environment variable "GOOGLE_CHROME_MAX_MEMORY".
+	GoogleChromeMaxMemoryEnvVar string = "GOOGLE_CHROME_MAX_MEMORY"
+	// GoogleChromeMaxScreenshotHeightEnvVar contains the name
+	// of the environment variable "GOOGLE_CHROME_MAX_SCREENSHOT_HEIGHT".
+	GoogleChromeMaxScreenshotHeightEnvVar string = "GOOGLE_CHROME_MAX_SCREENSHOT_HEIGHT"
+	// MaximumConcurrentProcessesEnvVar contains the name
+	// of the environment variable "MAXIMUM_CONCURRENT_PROCESSES".
+	MaximumConcurrentProcessesEnvVar string = "MAXIMUM_CONCURRENT_PROCESSES"
+	// MaximumQueueLengthEnvVar contains the name
+	// of the environment variable "MAXIMUM_QUEUE_LENGTH".
+	MaximumQueueLengthEnvVar string = "MAXIMUM_QUEUE_LENGTH"
+	// QueueWaitTimeoutEnvVar contains the name
+	// of the environment variable "QUEUE_WAIT_TIMEOUT".
+	QueueWaitTimeoutEnvVar string = "QUEUE_WAIT_TIMEOUT"
+	// MergeBackendEnvVar contains the name
+	// of the environment variable "MERGE_BACKEND".
+	MergeBackendEnvVar string = "MERGE_BACKEND"
 	// EnableAuthEnvVar contains the name
 	// of the environment variable "ENABLE_AUTH".
 	EnableAuthEnvVar string = "ENABLE_AUTH"
@@ -64,6 +90,33 @@ const (
 	// RequireHTTPSEnvVar contains the name
 	// of the environment variable "REQUIRE_HTTPS".
 	RequireHTTPSEnvVar string = "REQUIRE_HTTPS"
+	// JobResultTTLEnvVar contains the name
+	// of the environment variable "JOB_RESULT_TTL".
+	JobResultTTLEnvVar string = "JOB_RESULT_TTL"
+	// MaximumPendingJobsEnvVar contains the name
+	// of the environment variable "MAXIMUM_PENDING_JOBS".
+	MaximumPendingJobsEnvVar string = "MAXIMUM_PENDING_JOBS"
+	// GoogleChromeAllowedURLsEnvVar contains the name
+	// of the environment variable "GOOGLE_CHROME_ALLOWED_URLS".
+	GoogleChromeAllowedURLsEnvVar string = "GOOGLE_CHROME_ALLOWED_URLS"
+	// GoogleChromeDeniedURLsEnvVar contains the name
+	// of the environment variable "GOOGLE_CHROME_DENIED_URLS".
+	GoogleChromeDeniedURLsEnvVar string = "GOOGLE_CHROME_DENIED_URLS"
+	// GoogleChromeBlockedResourceTypesEnvVar contains the name
+	// of the environment variable "GOOGLE_CHROME_BLOCKED_RESOURCE_TYPES".
+	GoogleChromeBlockedResourceTypesEnvVar string = "GOOGLE_CHROME_BLOCKED_RESOURCE_TYPES"
+	// GoogleChromeBlockPrivateNetworksEnvVar contains the name
+	// of the environment variable "GOOGLE_CHROME_BLOCK_PRIVATE_NETWORKS".
+	GoogleChromeBlockPrivateNetworksEnvVar string = "GOOGLE_CHROME_BLOCK_PRIVATE_NETWORKS"
+	// DisableEgressPolicyEnvVar contains the name
+	// of the environment variable "DISABLE_EGRESS_POLICY".
+	DisableEgressPolicyEnvVar string = "DISABLE_EGRESS_POLICY"
+	// EgressAllowedHostsEnvVar contains the name
+	// of the environment variable "EGRESS_ALLOWED_HOSTS".
+	EgressAllowedHostsEnvVar string = "EGRESS_ALLOWED_HOSTS"
+	// EgressAllowedNetworksEnvVar contains the name
+	// of the environment variable "EGRESS_ALLOWED_NETWORKS".
+	EgressAllowedNetworksEnvVar string = "EGRESS_ALLOWED_NETWORKS"
 )
 
 // Config contains the application
@@ -80,6 +133,14 @@ type Config struct {
 	googleChromeIgnoreCertificateErrors bool
 	googleChromeMaxConnections          int64
 	googleChromeWaitForConnection       bool
+	googleChromeInstances               int64
+	googleChromeRecycleAfter            int64
+	googleChromeMaxMemory               int64
+	googleChromeMaxScreenshotHeight     int64
+	maximumConcurrentProcesses          int64
+	maximumQueueLength                  int64
+	queueWaitTimeout                    float64
+	mergeBackend                        string
 	logLevel                            xlog.Level
 	rootPath                            string
 	maximumGoogleChromeRpccBufferSize   int64
@@ -88,6 +149,69 @@ type Config struct {
 	authenticationUsername              string
 	authenticationPassword              string
 	requireHTTPS                        bool
+	jobResultTTL                        float64
+	maximumPendingJobs                  int64
+	googleChromeAllowedURLs             []string
+	googleChromeDeniedURLs              []string
+	googleChromeBlockedResourceTypes    []string
+	googleChromeBlockPrivateNetworks    bool
+	disableEgressPolicy                 bool
+	egressAllowedHosts                  []string
+	egressAllowedNetworks               []string
+}
+
+const (
+	// PDFtkMergeBackend merges PDF files
+	// with the pdftk binary.
+	PDFtkMergeBackend string = "pdftk"
+	// PDFcpuMergeBackend merges PDF files
+	// natively thanks to the pdfcpu library.
+	PDFcpuMergeBackend string = "pdfcpu"
+)
+
+const (
+	// ImageResourceType is the type
+	// of the images of a page.
+	ImageResourceType string = "image"
+	// FontResourceType is the type
+	// of the web fonts of a page.
+	FontResourceType string = "font"
+	// MediaResourceType is the type of
+	// the audios and videos of a page.
+	MediaResourceType string = "media"
+	// ScriptResourceType is the type
+	// of the scripts of a page.
+	ScriptResourceType string = "script"
+	// StylesheetResourceType is the type
+	// of the stylesheets of a page.
+	StylesheetResourceType string = "stylesheet"
+)
+
+/*
+ResourceTypes returns a slice
+containing all the resource types
+Google Chrome may block.
+*/
+func ResourceTypes() []string {
+	return []string{
+		ImageResourceType,
+		FontResourceType,
+		MediaResourceType,
+		ScriptResourceType,
+		StylesheetResourceType,
+	}
+}
+
+/*
+MergeBackends returns a slice
+containing all available merge
+backends.
+*/
+func MergeBackends() []string {
+	return []string{
+		PDFtkMergeBackend,
+		PDFcpuMergeBackend,
+	}
 }
 
 // DefaultConfig returns the default
@@ -109,10 +233,27 @@ func DefaultConfig() Config {
 		googleChromeIgnoreCertificateErrors: false,
 		googleChromeMaxConnections:          6,
 		googleChromeWaitForConnection:       true,
+		googleChromeInstances:               1,
+		googleChromeRecycleAfter:            0,
+		googleChromeMaxMemory:               0,
+		googleChromeMaxScreenshotHeight:     16384,
+		maximumConcurrentProcesses:          int64(runtime.NumCPU()),
+		maximumQueueLength:                  100,
+		queueWaitTimeout:                    30.0,
+		mergeBackend:                        PDFtkMergeBackend,
 		enableAuthentication:                false,
 		authenticationUsername:              "",
 		authenticationPassword:              "",
 		requireHTTPS:                        false,
+		jobResultTTL:                        3600.0,
+		maximumPendingJobs:                  1000,
+		googleChromeAllowedURLs:             nil,
+		googleChromeDeniedURLs:              nil,
+		googleChromeBlockedResourceTypes:    nil,
+		googleChromeBlockPrivateNetworks:    false,
+		disableEgressPolicy:                 false,
+		egressAllowedHosts:                  nil,
+		egressAllowedNetworks:               nil,
 	}
 }
 
@@ -262,6 +403,78 @@ func FromEnv() (Config, error) {
 		if err != nil {
 			return c, err
 		}
+		googleChromeInstances, err := xassert.Int64FromEnv(
+			GoogleChromeInstancesEnvVar,
+			c.googleChromeInstances,
+			xassert.Int64NotInferiorTo(1),
+		)
+		c.googleChromeInstances = googleChromeInstances
+		if err != nil {
+			return c, err
+		}
+		googleChromeRecycleAfter, err := xassert.Int64FromEnv(
+			GoogleChromeRecycleAfterEnvVar,
+			c.googleChromeRecycleAfter,
+			xassert.Int64NotInferiorTo(0),
+		)
+		c.googleChromeRecycleAfter = googleChromeRecycleAfter
+		if err != nil {
+			return c, err
+		}
+		googleChromeMaxMemory, err := xassert.BytesFromEnv(
+			GoogleChromeMaxMemoryEnvVar,
+			c.googleChromeMaxMemory,
+			xassert.Int64NotInferiorTo(0),
+		)
+		c.googleChromeMaxMemory = googleChromeMaxMemory
+		if err != nil {
+			return c, err
+		}
+		googleChromeMaxScreenshotHeight, err := xassert.Int64FromEnv(
+			GoogleChromeMaxScreenshotHeightEnvVar,
+			c.googleChromeMaxScreenshotHeight,
+			xassert.Int64NotInferiorTo(1),
+		)
+		c.googleChromeMaxScreenshotHeight = googleChromeMaxScreenshotHeight
+		if err != nil {
+			return c, err
+		}
+		maximumConcurrentProcesses, err := xassert.Int64FromEnv(
+			MaximumConcurrentProcessesEnvVar,
+			c.maximumConcurrentProcesses,
+			xassert.Int64NotInferiorTo(1),
+		)
+		c.maximumConcurrentProcesses = maximumConcurrentProcesses
+		if err != nil {
+			return c, err
+		}
+		maximumQueueLength, err := xassert.Int64FromEnv(
+			MaximumQueueLengthEnvVar,
+			c.maximumQueueLength,
+			xassert.Int64NotInferiorTo(0),
+		)
+		c.maximumQueueLength = maximumQueueLength
+		if err != nil {
+			return c, err
+		}
+		queueWaitTimeout, err := xassert.Float64FromEnv(
+			QueueWaitTimeoutEnvVar,
+			c.queueWaitTimeout,
+			xassert.Float64NotInferiorTo(0.0),
+		)
+		c.queueWaitTimeout = queueWaitTimeout
+		if err != nil {
+			return c, err
+		}
+		mergeBackend, err := xassert.StringFromEnv(
+			MergeBackendEnvVar,
+			c.mergeBackend,
+			xassert.StringOneOf(MergeBackends()),
+		)
+		c.mergeBackend = mergeBackend
+		if err != nil {
+			return c, err
+		}
 		enableAuthentication, err := xassert.BoolFromEnv(
 			EnableAuthEnvVar,
 			c.enableAuthentication,
@@ -294,6 +507,82 @@ func FromEnv() (Config, error) {
 		if err != nil {
 			return c, err
 		}
+		jobResultTTL, err := xassert.Float64FromEnv(
+			JobResultTTLEnvVar,
+			c.jobResultTTL,
+			xassert.Float64SuperiorTo(0.0),
+		)
+		c.jobResultTTL = jobResultTTL
+		if err != nil {
+			return c, err
+		}
+		maximumPendingJobs, err := xassert.Int64FromEnv(
+			MaximumPendingJobsEnvVar,
+			c.maximumPendingJobs,
+			xassert.Int64NotInferiorTo(1),
+		)
+		c.maximumPendingJobs = maximumPendingJobs
+		if err != nil {
+			return c, err
+		}
+		googleChromeAllowedURLs, err := xassert.StringsFromEnv(
+			GoogleChromeAllowedURLsEnvVar,
+			c.googleChromeAllowedURLs,
+		)
+		c.googleChromeAllowedURLs = googleChromeAllowedURLs
+		if err != nil {
+			return c, err
+		}
+		googleChromeDeniedURLs, err := xassert.StringsFromEnv(
+			GoogleChromeDeniedURLsEnvVar,
+			c.googleChromeDeniedURLs,
+		)
+		c.googleChromeDeniedURLs = googleChromeDeniedURLs
+		if err != nil {
+			return c, err
+		}
+		googleChromeBlockedResourceTypes, err := xassert.StringsFromEnv(
+			GoogleChromeBlockedResourceTypesEnvVar,
+			c.googleChromeBlockedResourceTypes,
+			xassert.StringOneOf(ResourceTypes()),
+		)
+		c.googleChromeBlockedResourceTypes = googleChromeBlockedResourceTypes
+		if err != nil {
+			return c, err
+		}
+		googleChromeBlockPrivateNetworks, err := xassert.BoolFromEnv(
+			GoogleChromeBlockPrivateNetworksEnvVar,
+			c.googleChromeBlockPrivateNetworks,
+		)
+		c.googleChromeBlockPrivateNetworks = googleChromeBlockPrivateNetworks
+		if err != nil {
+			return c, err
+		}
+		disableEgressPolicy, err := xassert.BoolFromEnv(
+			DisableEgressPolicyEnvVar,
+			c.disableEgressPolicy,
+		)
+		c.disableEgressPolicy = disableEgressPolicy
+		if err != nil {
+			return c, err
+		}
+		egressAllowedHosts, err := xassert.StringsFromEnv(
+			EgressAllowedHostsEnvVar,
+			c.egressAllowedHosts,
+		)
+		c.egressAllowedHosts = egressAllowedHosts
+		if err != nil {
+			return c, err
+		}
+		egressAllowedNetworks, err := xassert.StringsFromEnv(
+			EgressAllowedNetworksEnvVar,
+			c.egressAllowedNetworks,
+			xassert.StringCIDR(),
+		)
+		c.egressAllowedNetworks = egressAllowedNetworks
+		if err != nil {
+			return c, err
+		}
 		return c, nil
 	}
 	result, err := resolver()
@@ -393,6 +682,73 @@ func (c Config) GoogleChromeWaitForConnection() bool {
 	return c.googleChromeWaitForConnection
 }
 
+// GoogleChromeInstances returns the number of
+// Google Chrome processes from the configuration.
+func (c Config) GoogleChromeInstances() int64 {
+	return c.googleChromeInstances
+}
+
+/*
+GoogleChromeRecycleAfter returns the number of
+conversions after which a Google Chrome process
+is restarted.
+
+0 means never.
+*/
+func (c Config) GoogleChromeRecycleAfter() int64 {
+	return c.googleChromeRecycleAfter
+}
+
+/*
+GoogleChromeMaxMemory returns the memory (RSS, in bytes)
+above which a Google Chrome process is restarted.
+
+0 means no limit.
+*/
+func (c Config) GoogleChromeMaxMemory() int64 {
+	return c.googleChromeMaxMemory
+}
+
+// GoogleChromeMaxScreenshotHeight returns the
+// maximum height, in pixels, of a screenshot.
+func (c Config) GoogleChromeMaxScreenshotHeight() int64 {
+	return c.googleChromeMaxScreenshotHeight
+}
+
+/*
+MaximumConcurrentProcesses returns the maximum
+number of concurrent unoconv and PDFtk processes
+from the configuration.
+*/
+func (c Config) MaximumConcurrentProcesses() int64 {
+	return c.maximumConcurrentProcesses
+}
+
+/*
+MaximumQueueLength returns the maximum number
+of conversions waiting for a free slot from
+the configuration.
+*/
+func (c Config) MaximumQueueLength() int64 {
+	return c.maximumQueueLength
+}
+
+/*
+QueueWaitTimeout returns the duration in seconds
+during which a conversion may wait for a free slot.
+
+0 means until the conversion times out.
+*/
+func (c Config) QueueWaitTimeout() float64 {
+	return c.queueWaitTimeout
+}
+
+// MergeBackend returns the backend used
+// for merging PDF files.
+func (c Config) MergeBackend() string {
+	return c.mergeBackend
+}
+
 // EnableAuthentication returns the bool from
 // the configuration.
 func (c Config) EnableAuthentication() bool {
@@ -416,3 +772,72 @@ func (c Config) AuthenticationPassword() string {
 func (c Config) RequireHTTPS() bool {
 	return c.requireHTTPS
 }
+
+// JobResultTTL returns the duration in seconds
+// during which a job result is kept.
+func (c Config) JobResultTTL() float64 {
+	return c.jobResultTTL
+}
+
+// MaximumPendingJobs returns the maximum
+// number of queued or running jobs.
+func (c Config) MaximumPendingJobs() int64 {
+	return c.maximumPendingJobs
+}
+
+/*
+GoogleChromeAllowedURLs returns the URL patterns
+of the only requests Google Chrome may send.
+
+Empty means all.
+*/
+func (c Config) GoogleChromeAllowedURLs() []string {
+	return c.googleChromeAllowedURLs
+}
+
+// GoogleChromeDeniedURLs returns the URL patterns
+// of the requests Google Chrome should block.
+func (c Config) GoogleChromeDeniedURLs() []string {
+	return c.googleChromeDeniedURLs
+}
+
+// GoogleChromeBlockedResourceTypes returns the
+// resource types Google Chrome should block.
+func (c Config) GoogleChromeBlockedResourceTypes() []string {
+	return c.googleChromeBlockedResourceTypes
+}
+
+/*
+GoogleChromeBlockPrivateNetworks returns true if
+Google Chrome should block the requests to the
+loopback, private and link-local addresses.
+*/
+func (c Config) GoogleChromeBlockPrivateNetworks() bool {
+	return c.googleChromeBlockPrivateNetworks
+}
+
+/*
+DisableEgressPolicy returns true if the API
+may send requests to the private addresses
+without restriction.
+*/
+func (c Config) DisableEgressPolicy() bool {
+	return c.disableEgressPolicy
+}
+
+/*
+EgressAllowedHosts returns the hosts the API
+may send requests to, even if they resolve to
+private addresses.
+
+A host starting with "*." matches its subdomains.
+*/
+func (c Config) EgressAllowedHosts() []string {
+	return c.egressAllowedHosts
+}
+
+// EgressAllowedNetworks returns the IP networks, in
+// CIDR notation, the API may send requests to.
+func (c Config) EgressAllowedNetworks() []string {
+	return c.egressAllowedNetworks
+}
@@ -489,6 +489,377 @@ func TestGoogleChromeIgnoreCertificateErrorsFromEnv(t *testing.T) {
 	assert.Equal(t, expected, result)
 }
 
+func TestJobResultTTLFromEnv(t *testing.T) {
+	var (
+		expected Config
+		result   Config
+		err      error
+	)
+	// JOB_RESULT_TTL correctly set.
+	os.Setenv(JobResultTTLEnvVar, "60.0")
+	expected = DefaultConfig()
+	expected.jobResultTTL = 60.0
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(JobResultTTLEnvVar)
+	// JOB_RESULT_TTL wrongly set.
+	os.Setenv(JobResultTTLEnvVar, "foo")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(JobResultTTLEnvVar)
+	// JOB_RESULT_TTL < 0.
+	os.Setenv(JobResultTTLEnvVar, "-1.0")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(JobResultTTLEnvVar)
+	// JOB_RESULT_TTL = 0.
+	os.Setenv(JobResultTTLEnvVar, "0")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(JobResultTTLEnvVar)
+}
+
+func TestMaximumPendingJobsFromEnv(t *testing.T) {
+	var (
+		expected Config
+		result   Config
+		err      error
+	)
+	// MAXIMUM_PENDING_JOBS correctly set.
+	os.Setenv(MaximumPendingJobsEnvVar, "10")
+	expected = DefaultConfig()
+	expected.maximumPendingJobs = 10
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(MaximumPendingJobsEnvVar)
+	// MAXIMUM_PENDING_JOBS wrongly set.
+	os.Setenv(MaximumPendingJobsEnvVar, "foo")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(MaximumPendingJobsEnvVar)
+	// MAXIMUM_PENDING_JOBS < 1.
+	os.Setenv(MaximumPendingJobsEnvVar, "0")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(MaximumPendingJobsEnvVar)
+}
+
+func TestGoogleChromeRequestFiltersFromEnv(t *testing.T) {
+	var (
+		expected Config
+		result   Config
+		err      error
+	)
+	// GOOGLE_CHROME_ALLOWED_URLS, GOOGLE_CHROME_DENIED_URLS,
+	// GOOGLE_CHROME_BLOCKED_RESOURCE_TYPES and
+	// GOOGLE_CHROME_BLOCK_PRIVATE_NETWORKS correctly set.
+	os.Setenv(GoogleChromeAllowedURLsEnvVar, "file://*, https://*.example.com/*")
+	os.Setenv(GoogleChromeDeniedURLsEnvVar, "https://tracker.example.com/*")
+	os.Setenv(GoogleChromeBlockedResourceTypesEnvVar, "media,font")
+	os.Setenv(GoogleChromeBlockPrivateNetworksEnvVar, "true")
+	expected = DefaultConfig()
+	expected.googleChromeAllowedURLs = []string{"file://*", "https://*.example.com/*"}
+	expected.googleChromeDeniedURLs = []string{"https://tracker.example.com/*"}
+	expected.googleChromeBlockedResourceTypes = []string{MediaResourceType, FontResourceType}
+	expected.googleChromeBlockPrivateNetworks = true
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeAllowedURLsEnvVar)
+	os.Unsetenv(GoogleChromeDeniedURLsEnvVar)
+	os.Unsetenv(GoogleChromeBlockedResourceTypesEnvVar)
+	os.Unsetenv(GoogleChromeBlockPrivateNetworksEnvVar)
+	// GOOGLE_CHROME_BLOCKED_RESOURCE_TYPES wrongly set.
+	os.Setenv(GoogleChromeBlockedResourceTypesEnvVar, "media,foo")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeBlockedResourceTypesEnvVar)
+	// GOOGLE_CHROME_BLOCK_PRIVATE_NETWORKS wrongly set.
+	os.Setenv(GoogleChromeBlockPrivateNetworksEnvVar, "foo")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeBlockPrivateNetworksEnvVar)
+}
+
+func TestEgressPolicyFromEnv(t *testing.T) {
+	var (
+		expected Config
+		result   Config
+		err      error
+	)
+	// DISABLE_EGRESS_POLICY, EGRESS_ALLOWED_HOSTS
+	// and EGRESS_ALLOWED_NETWORKS correctly set.
+	os.Setenv(DisableEgressPolicyEnvVar, "true")
+	os.Setenv(EgressAllowedHostsEnvVar, "webhook.internal,*.corp.example.com")
+	os.Setenv(EgressAllowedNetworksEnvVar, "10.1.0.0/16")
+	expected = DefaultConfig()
+	expected.disableEgressPolicy = true
+	expected.egressAllowedHosts = []string{"webhook.internal", "*.corp.example.com"}
+	expected.egressAllowedNetworks = []string{"10.1.0.0/16"}
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(DisableEgressPolicyEnvVar)
+	os.Unsetenv(EgressAllowedHostsEnvVar)
+	os.Unsetenv(EgressAllowedNetworksEnvVar)
+	// DISABLE_EGRESS_POLICY wrongly set.
+	os.Setenv(DisableEgressPolicyEnvVar, "foo")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(DisableEgressPolicyEnvVar)
+	// EGRESS_ALLOWED_NETWORKS wrongly set.
+	os.Setenv(EgressAllowedNetworksEnvVar, "10.1.0.0")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(EgressAllowedNetworksEnvVar)
+}
+
+func TestGoogleChromeInstancesFromEnv(t *testing.T) {
+	var (
+		expected Config
+		result   Config
+		err      error
+	)
+	// GOOGLE_CHROME_INSTANCES correctly set.
+	os.Setenv(GoogleChromeInstancesEnvVar, "4")
+	expected = DefaultConfig()
+	expected.googleChromeInstances = 4
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeInstancesEnvVar)
+	// GOOGLE_CHROME_INSTANCES wrongly set.
+	os.Setenv(GoogleChromeInstancesEnvVar, "foo")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeInstancesEnvVar)
+	// GOOGLE_CHROME_INSTANCES < 1.
+	os.Setenv(GoogleChromeInstancesEnvVar, "0")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeInstancesEnvVar)
+}
+
+func TestGoogleChromeRecycleAfterFromEnv(t *testing.T) {
+	var (
+		expected Config
+		result   Config
+		err      error
+	)
+	// GOOGLE_CHROME_RECYCLE_AFTER correctly set.
+	os.Setenv(GoogleChromeRecycleAfterEnvVar, "100")
+	expected = DefaultConfig()
+	expected.googleChromeRecycleAfter = 100
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeRecycleAfterEnvVar)
+	// GOOGLE_CHROME_RECYCLE_AFTER wrongly set.
+	os.Setenv(GoogleChromeRecycleAfterEnvVar, "foo")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeRecycleAfterEnvVar)
+	// GOOGLE_CHROME_RECYCLE_AFTER < 0.
+	os.Setenv(GoogleChromeRecycleAfterEnvVar, "-1")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeRecycleAfterEnvVar)
+}
+
+func TestGoogleChromeMaxScreenshotHeightFromEnv(t *testing.T) {
+	var (
+		expected Config
+		result   Config
+		err      error
+	)
+	// GOOGLE_CHROME_MAX_SCREENSHOT_HEIGHT correctly set.
+	os.Setenv(GoogleChromeMaxScreenshotHeightEnvVar, "4096")
+	expected = DefaultConfig()
+	expected.googleChromeMaxScreenshotHeight = 4096
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeMaxScreenshotHeightEnvVar)
+	// GOOGLE_CHROME_MAX_SCREENSHOT_HEIGHT wrongly set.
+	os.Setenv(GoogleChromeMaxScreenshotHeightEnvVar, "foo")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeMaxScreenshotHeightEnvVar)
+	// GOOGLE_CHROME_MAX_SCREENSHOT_HEIGHT < 1.
+	os.Setenv(GoogleChromeMaxScreenshotHeightEnvVar, "0")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeMaxScreenshotHeightEnvVar)
+}
+
+func TestGoogleChromeMaxMemoryFromEnv(t *testing.T) {
+	var (
+		expected Config
+		result   Config
+		err      error
+	)
+	// GOOGLE_CHROME_MAX_MEMORY correctly set.
+	os.Setenv(GoogleChromeMaxMemoryEnvVar, "512MB")
+	expected = DefaultConfig()
+	expected.googleChromeMaxMemory = 512000000
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeMaxMemoryEnvVar)
+	// GOOGLE_CHROME_MAX_MEMORY wrongly set.
+	os.Setenv(GoogleChromeMaxMemoryEnvVar, "foo")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeMaxMemoryEnvVar)
+}
+
+func TestMaximumConcurrentProcessesFromEnv(t *testing.T) {
+	var (
+		expected Config
+		result   Config
+		err      error
+	)
+	// MAXIMUM_CONCURRENT_PROCESSES correctly set.
+	os.Setenv(MaximumConcurrentProcessesEnvVar, "2")
+	expected = DefaultConfig()
+	expected.maximumConcurrentProcesses = 2
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(MaximumConcurrentProcessesEnvVar)
+	// MAXIMUM_CONCURRENT_PROCESSES wrongly set.
+	os.Setenv(MaximumConcurrentProcessesEnvVar, "foo")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(MaximumConcurrentProcessesEnvVar)
+	// MAXIMUM_CONCURRENT_PROCESSES < 1.
+	os.Setenv(MaximumConcurrentProcessesEnvVar, "0")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(MaximumConcurrentProcessesEnvVar)
+}
+
+func TestMaximumQueueLengthFromEnv(t *testing.T) {
+	var (
+		expected Config
+		result   Config
+		err      error
+	)
+	// MAXIMUM_QUEUE_LENGTH correctly set.
+	os.Setenv(MaximumQueueLengthEnvVar, "10")
+	expected = DefaultConfig()
+	expected.maximumQueueLength = 10
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(MaximumQueueLengthEnvVar)
+	// MAXIMUM_QUEUE_LENGTH wrongly set.
+	os.Setenv(MaximumQueueLengthEnvVar, "foo")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(MaximumQueueLengthEnvVar)
+	// MAXIMUM_QUEUE_LENGTH < 0.
+	os.Setenv(MaximumQueueLengthEnvVar, "-1")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(MaximumQueueLengthEnvVar)
+}
+
+func TestQueueWaitTimeoutFromEnv(t *testing.T) {
+	var (
+		expected Config
+		result   Config
+		err      error
+	)
+	// QUEUE_WAIT_TIMEOUT correctly set.
+	os.Setenv(QueueWaitTimeoutEnvVar, "5.0")
+	expected = DefaultConfig()
+	expected.queueWaitTimeout = 5.0
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(QueueWaitTimeoutEnvVar)
+	// QUEUE_WAIT_TIMEOUT wrongly set.
+	os.Setenv(QueueWaitTimeoutEnvVar, "foo")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(QueueWaitTimeoutEnvVar)
+	// QUEUE_WAIT_TIMEOUT < 0.
+	os.Setenv(QueueWaitTimeoutEnvVar, "-1.0")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(QueueWaitTimeoutEnvVar)
+}
+
+func TestMergeBackendFromEnv(t *testing.T) {
+	var (
+		expected Config
+		result   Config
+		err      error
+	)
+	// MERGE_BACKEND correctly set.
+	os.Setenv(MergeBackendEnvVar, "pdfcpu")
+	expected = DefaultConfig()
+	expected.mergeBackend = PDFcpuMergeBackend
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(MergeBackendEnvVar)
+	// MERGE_BACKEND wrongly set.
+	os.Setenv(MergeBackendEnvVar, "foo")
+	expected = DefaultConfig()
+	result, err = FromEnv()
+	test.AssertError(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(MergeBackendEnvVar)
+}
+
 func TestGetters(t *testing.T) {
 	result := DefaultConfig()
 	assert.Equal(t, result.maximumWaitTimeout, result.MaximumWaitTimeout())
@@ -507,4 +878,19 @@ func TestGetters(t *testing.T) {
 	assert.Equal(t, result.maximumGoogleChromeRpccBufferSize, result.MaximumGoogleChromeRpccBufferSize())
 	assert.Equal(t, result.defaultGoogleChromeRpccBufferSize, result.DefaultGoogleChromeRpccBufferSize())
 	assert.Equal(t, result.googleChromeIgnoreCertificateErrors, result.GoogleChromeIgnoreCertificateErrors())
+	assert.Equal(t, result.jobResultTTL, result.JobResultTTL())
+	assert.Equal(t, result.googleChromeAllowedURLs, result.GoogleChromeAllowedURLs())
+	assert.Equal(t, result.googleChromeDeniedURLs, result.GoogleChromeDeniedURLs())
+	assert.Equal(t, result.googleChromeBlockedResourceTypes, result.GoogleChromeBlockedResourceTypes())
+	assert.Equal(t, result.googleChromeBlockPrivateNetworks, result.GoogleChromeBlockPrivateNetworks())
+	assert.Equal(t, result.disableEgressPolicy, result.DisableEgressPolicy())
+	assert.Equal(t, result.egressAllowedHosts, result.EgressAllowedHosts())
+	assert.Equal(t, result.egressAllowedNetworks, result.EgressAllowedNetworks())
+	assert.Equal(t, result.googleChromeInstances, result.GoogleChromeInstances())
+	assert.Equal(t, result.googleChromeRecycleAfter, result.GoogleChromeRecycleAfter())
+	assert.Equal(t, result.googleChromeMaxMemory, result.GoogleChromeMaxMemory())
+	assert.Equal(t, result.maximumConcurrentProcesses, result.MaximumConcurrentProcesses())
+	assert.Equal(t, result.maximumQueueLength, result.MaximumQueueLength())
+	assert.Equal(t, result.queueWaitTimeout, result.QueueWaitTimeout())
+	assert.Equal(t, result.mergeBackend, result.MergeBackend())
 }
@@ -0,0 +1,10 @@
+/*
+Package egress decides which hosts the API
+may send requests to, so that a caller cannot
+reach internal services thanks to a conversion
+or a webhook.
+
+All functions return our standard xerror.Error
+in case of error.
+*/
+package egress
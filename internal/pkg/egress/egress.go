@@ -0,0 +1,256 @@
+package egress
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+
+	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+)
+
+// metadataNetworks are the addresses of the cloud
+// metadata endpoints which are neither private
+// nor link-local.
+var metadataNetworks = mustParseCIDRs(
+	// Alibaba Cloud.
+	"100.100.100.200/32",
+)
+
+// unspecifiedNetworks are the addresses of "this
+// network", which may reach the local host.
+var unspecifiedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+)
+
+/*
+Policy denies the requests to the loopback,
+private, link-local and cloud metadata
+addresses, unless their host or their
+network is explicitly allowed.
+
+A nil *Policy allows everything.
+*/
+type Policy struct {
+	allowedHosts    []string
+	allowedNetworks []*net.IPNet
+}
+
+/*
+New returns the Policy from the configuration,
+or nil if the egress policy is disabled.
+*/
+func New(config conf.Config) *Policy {
+	if config.DisableEgressPolicy() {
+		return nil
+	}
+	p := &Policy{}
+	for _, host := range config.EgressAllowedHosts() {
+		p.allowedHosts = append(p.allowedHosts, strings.ToLower(host))
+	}
+	for _, cidr := range config.EgressAllowedNetworks() {
+		// the configuration has
+		// already validated them.
+		if _, network, err := net.ParseCIDR(cidr); err == nil {
+			p.allowedNetworks = append(p.allowedNetworks, network)
+		}
+	}
+	return p
+}
+
+/*
+IsPrivateIP returns true if the given IP
+address is not a public one, i.e. a loopback,
+private, link-local, unspecified or cloud
+metadata address.
+*/
+func IsPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsUnspecified() ||
+		contains(unspecifiedNetworks, ip) ||
+		contains(metadataNetworks, ip)
+}
+
+/*
+CheckURL returns an error with
+xerror.ForbiddenCode if the given URL is
+not an HTTP(S) URL or if its host is denied.
+*/
+func (p *Policy) CheckURL(ctx context.Context, rawURL string) error {
+	const op string = "egress.Policy.CheckURL"
+	if p == nil {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return xerror.Invalid(op, fmt.Sprintf("'%s' is not a valid URL", rawURL), err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return xerror.Forbidden(op, fmt.Sprintf("'%s' is not an HTTP(S) URL", rawURL), nil)
+	}
+	if err := p.CheckHost(ctx, u.Hostname()); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+CheckHost returns an error with
+xerror.ForbiddenCode if the given host is
+denied, i.e. if it is not allowed and it is,
+or resolves to, a private IP address.
+
+A host which does not resolve is not denied,
+as no request may reach it.
+*/
+func (p *Policy) CheckHost(ctx context.Context, host string) error {
+	const op string = "egress.Policy.CheckHost"
+	if p == nil || p.isAllowedHost(host) {
+		return nil
+	}
+	// Google Chrome resolves these hosts to
+	// the loopback address by itself.
+	lower := strings.ToLower(host)
+	if lower == "localhost" || strings.HasSuffix(lower, ".localhost") {
+		return xerror.Forbidden(op, fmt.Sprintf("the host '%s' is denied", host), nil)
+	}
+	if ip := net.ParseIP(strings.Trim(host, "[]")); ip != nil {
+		if err := p.CheckIP(ip); err != nil {
+			return xerror.New(op, err)
+		}
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if err := p.CheckIP(addr.IP); err != nil {
+			return xerror.Forbidden(
+				op,
+				fmt.Sprintf("the host '%s' resolves to the denied address '%s'", host, addr.IP),
+				err,
+			)
+		}
+	}
+	return nil
+}
+
+/*
+CheckIP returns an error with
+xerror.ForbiddenCode if the given IP address
+is private and not in an allowed network.
+*/
+func (p *Policy) CheckIP(ip net.IP) error {
+	const op string = "egress.Policy.CheckIP"
+	if p == nil || contains(p.allowedNetworks, ip) || !IsPrivateIP(ip) {
+		return nil
+	}
+	return xerror.Forbidden(op, fmt.Sprintf("the address '%s' is denied", ip), nil)
+}
+
+/*
+CheckRemoteIP returns an error with
+xerror.ForbiddenCode if the given host is not
+allowed and the given IP address, at which it
+has actually been reached, is denied.
+
+Unlike CheckHost, it does not trust a previous
+DNS lookup, which a DNS record with a short TTL
+may defeat.
+*/
+func (p *Policy) CheckRemoteIP(host string, ip net.IP) error {
+	const op string = "egress.Policy.CheckRemoteIP"
+	if p == nil || p.isAllowedHost(host) {
+		return nil
+	}
+	if err := p.CheckIP(ip); err != nil {
+		return xerror.Forbidden(
+			op,
+			fmt.Sprintf("the host '%s' has been reached at the denied address '%s'", host, ip),
+			err,
+		)
+	}
+	return nil
+}
+
+/*
+DialContext returns a function for the
+DialContext field of an http.Transport which
+checks the IP address of each connection
+against the policy.
+
+As it checks the address actually dialed,
+it also applies to the redirects and to the
+DNS records which change after a check.
+*/
+func (p *Policy) DialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		const op string = "egress.Policy.DialContext"
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, xerror.New(op, err)
+		}
+		d := *dialer
+		if p != nil && !p.isAllowedHost(host) {
+			d.Control = func(network, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				return p.CheckIP(net.ParseIP(host))
+			}
+		}
+		conn, err := d.DialContext(ctx, network, address)
+		if err != nil {
+			return nil, xerror.New(op, err)
+		}
+		return conn, nil
+	}
+}
+
+/*
+isAllowedHost returns true if the given host
+is one of the allowed hosts, or a subdomain of
+an allowed host starting with "*.".
+*/
+func (p *Policy) isAllowedHost(host string) bool {
+	host = strings.ToLower(strings.Trim(host, "[]"))
+	for _, allowed := range p.allowedHosts {
+		if host == allowed {
+			return true
+		}
+		if strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
@@ -0,0 +1,165 @@
+package egress
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestNew(t *testing.T) {
+	// should deny the private
+	// addresses by default.
+	p := New(conf.DefaultConfig())
+	assert.NotNil(t, p)
+	// should be nil as the egress
+	// policy is disabled.
+	os.Setenv(conf.DisableEgressPolicyEnvVar, "true")
+	config, err := conf.FromEnv()
+	assert.Nil(t, err)
+	assert.Nil(t, New(config))
+	os.Unsetenv(conf.DisableEgressPolicyEnvVar)
+	// should have the allowed hosts
+	// and networks.
+	os.Setenv(conf.EgressAllowedHostsEnvVar, "Webhook.Internal")
+	os.Setenv(conf.EgressAllowedNetworksEnvVar, "10.1.0.0/16")
+	config, err = conf.FromEnv()
+	assert.Nil(t, err)
+	p = New(config)
+	assert.Equal(t, []string{"webhook.internal"}, p.allowedHosts)
+	assert.Len(t, p.allowedNetworks, 1)
+	os.Unsetenv(conf.EgressAllowedHostsEnvVar)
+	os.Unsetenv(conf.EgressAllowedNetworksEnvVar)
+}
+
+func TestIsPrivateIP(t *testing.T) {
+	for _, ip := range []string{
+		"127.0.0.1",
+		"10.1.2.3",
+		"172.16.0.1",
+		"192.168.1.1",
+		"169.254.169.254",
+		"100.100.100.200",
+		"0.0.0.0",
+		"0.1.2.3",
+		"::1",
+		"fd00:ec2::254",
+		"fe80::1",
+		"::ffff:127.0.0.1",
+	} {
+		assert.True(t, IsPrivateIP(net.ParseIP(ip)), ip)
+	}
+	for _, ip := range []string{
+		"93.184.216.34",
+		"100.64.0.1",
+		"2606:2800:220:1:248:1893:25c8:1946",
+	} {
+		assert.False(t, IsPrivateIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	ctx := context.Background()
+	p := &Policy{
+		allowedHosts:    []string{"webhook.internal", "*.corp.example.com"},
+		allowedNetworks: mustParseCIDRs("10.1.0.0/16"),
+	}
+	// should be OK as the address is public.
+	assert.Nil(t, p.CheckURL(ctx, "https://93.184.216.34/"))
+	// should be OK as the hosts are allowed,
+	// without resolving them.
+	assert.Nil(t, p.CheckURL(ctx, "http://webhook.internal:8080/foo"))
+	assert.Nil(t, p.CheckURL(ctx, "https://app.corp.example.com/"))
+	// should be OK as the network is allowed.
+	assert.Nil(t, p.CheckURL(ctx, "http://10.1.2.3/"))
+	// should be OK as there is no policy.
+	var nilPolicy *Policy
+	assert.Nil(t, nilPolicy.CheckURL(ctx, "file:///etc/passwd"))
+	// should not be OK as the addresses
+	// are private.
+	for _, u := range []string{
+		"http://127.0.0.1:3000/",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://[::1]/",
+		"http://10.2.0.1/",
+		"http://localhost/",
+		"http://corp.example.com.localhost/",
+	} {
+		err := p.CheckURL(ctx, u)
+		test.AssertError(t, err)
+		assert.Equal(t, xerror.ForbiddenCode, xerror.Code(err), u)
+	}
+	// should not be OK as the URLs
+	// are not HTTP(S) URLs.
+	err := p.CheckURL(ctx, "file:///etc/passwd")
+	assert.Equal(t, xerror.ForbiddenCode, xerror.Code(err))
+	err = p.CheckURL(ctx, "gopher://127.0.0.1:6379/")
+	assert.Equal(t, xerror.ForbiddenCode, xerror.Code(err))
+}
+
+func TestCheckRemoteIP(t *testing.T) {
+	p := &Policy{
+		allowedHosts:    []string{"webhook.internal"},
+		allowedNetworks: mustParseCIDRs("10.1.0.0/16"),
+	}
+	// should be OK as the address is public.
+	assert.Nil(t, p.CheckRemoteIP("example.com", net.ParseIP("93.184.216.34")))
+	// should be OK as the host is allowed.
+	assert.Nil(t, p.CheckRemoteIP("webhook.internal", net.ParseIP("127.0.0.1")))
+	// should be OK as the network is allowed.
+	assert.Nil(t, p.CheckRemoteIP("example.com", net.ParseIP("10.1.2.3")))
+	// should be OK as there is no policy.
+	var nilPolicy *Policy
+	assert.Nil(t, nilPolicy.CheckRemoteIP("example.com", net.ParseIP("127.0.0.1")))
+	// should not be OK as the host has been
+	// rebound to a private address.
+	err := p.CheckRemoteIP("example.com", net.ParseIP("169.254.169.254"))
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.ForbiddenCode, xerror.Code(err))
+}
+
+func TestDialContext(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	ctx := context.Background()
+	address := ln.Addr().String()
+	// should not be OK as the address
+	// is private.
+	dial := (&Policy{}).DialContext(&net.Dialer{})
+	_, err = dial(ctx, "tcp", address)
+	test.AssertError(t, err)
+	// should be OK as the network
+	// is allowed.
+	dial = (&Policy{allowedNetworks: mustParseCIDRs("127.0.0.0/8")}).DialContext(&net.Dialer{})
+	conn, err := dial(ctx, "tcp", address)
+	assert.Nil(t, err)
+	conn.Close()
+	// should be OK as the host
+	// is allowed.
+	dial = (&Policy{allowedHosts: []string{"127.0.0.1"}}).DialContext(&net.Dialer{})
+	conn, err = dial(ctx, "tcp", address)
+	assert.Nil(t, err)
+	conn.Close()
+	// should be OK as there
+	// is no policy.
+	var nilPolicy *Policy
+	dial = nilPolicy.DialContext(&net.Dialer{})
+	conn, err = dial(ctx, "tcp", address)
+	assert.Nil(t, err)
+	conn.Close()
+}
@@ -0,0 +1,85 @@
+package printer
+
+import (
+	"context"
+
+	"github.com/mafredri/cdp"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+)
+
+const (
+	// MissingLangWarningCode is the code of the
+	// warning for a document without language.
+	MissingLangWarningCode string = "missing-lang"
+	// MissingTitleWarningCode is the code of the
+	// warning for a document without title.
+	MissingTitleWarningCode string = "missing-title"
+	// MissingAltWarningCode is the code of the
+	// warning for an image without alternative text.
+	MissingAltWarningCode string = "missing-alt"
+)
+
+/*
+accessibilityCheckExpression lists the elements
+of the page which lack a language, a title or an
+alternative text, as required by PDF/UA.
+
+Images hidden from assistive technologies or
+labelled by ARIA attributes are not reported.
+As the warnings end up in a header, only the
+first images without alternative text are.
+*/
+const accessibilityCheckExpression string = `(() => {
+	const warnings = [];
+	const describe = (el) => {
+		let description = el.tagName.toLowerCase();
+		if (el.id) {
+			description += '#' + el.id;
+		}
+		const src = el.getAttribute('src') || el.getAttribute('href');
+		if (src) {
+			description += '[' + (el.hasAttribute('src') ? 'src' : 'href') + '="' + src.slice(0, 100) + '"]';
+		}
+		return description;
+	};
+	const lang = document.documentElement.getAttribute('lang');
+	if (!lang || !lang.trim()) {
+		warnings.push({code: '` + MissingLangWarningCode + `', message: 'the document has no lang attribute', element: 'html'});
+	}
+	if (!document.title.trim()) {
+		warnings.push({code: '` + MissingTitleWarningCode + `', message: 'the document has no title', element: 'title'});
+	}
+	const images = Array.from(document.querySelectorAll('img:not([alt]), area:not([alt]), input[type="image"]:not([alt])'));
+	images.filter((el) => {
+		const role = el.getAttribute('role');
+		if (role === 'presentation' || role === 'none' || el.getAttribute('aria-hidden') === 'true' ||
+			el.hasAttribute('aria-label') || el.hasAttribute('aria-labelledby')) {
+			return false;
+		}
+		return true;
+	}).slice(0, 20).forEach((el) => {
+		warnings.push({code: '` + MissingAltWarningCode + `', message: 'the image has no alt attribute', element: describe(el)});
+	});
+	return warnings;
+})()`
+
+/*
+checkAccessibility reports the accessibility
+issues of the loaded page as warnings.
+*/
+func (p chromePrinter) checkAccessibility(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.checkAccessibility"
+	resolver := func() error {
+		var result []Warning
+		if err := Eval(ctx, client, accessibilityCheckExpression, &result); err != nil {
+			return err
+		}
+		p.logger.DebugOpf(op, "%d accessibility issue(s) found", len(result))
+		p.report.addWarnings(result...)
+		return nil
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
@@ -17,7 +17,10 @@ import (
 	"github.com/mafredri/cdp/protocol/runtime"
 	"github.com/mafredri/cdp/protocol/target"
 	"github.com/mafredri/cdp/rpcc"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/admission"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/chrome"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/egress"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xcontext"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
@@ -29,6 +32,12 @@ type chromePrinter struct {
 	logger xlog.Logger
 	url    string
 	opts   ChromePrinterOptions
+	// screenshotOpts is nil if
+	// the result should be a PDF.
+	screenshotOpts *ScreenshotOptions
+	// report is shared by the copies
+	// of the chromePrinter.
+	report *report
 }
 
 // ChromePrinterOptions helps customizing the
@@ -37,21 +46,120 @@ type ChromePrinterOptions struct {
 	WaitTimeout        float64
 	WaitDelay          float64
 	WaitJSRenderStatus string
-	HeaderHTML         string
-	FooterHTML         string
-	PaperWidth         float64
-	PaperHeight        float64
-	MarginTop          float64
-	MarginBottom       float64
-	MarginLeft         float64
-	MarginRight        float64
-	Landscape          bool
-	PageRanges         string
-	RpccBufferSize     int64
-	CustomHTTPHeaders  map[string]string
-	Scale              float64
-	MaxConnections     int64
-	WaitForConnection  bool
+	// WaitForSelector is the CSS selector of
+	// an element to wait for, if not empty.
+	WaitForSelector string
+	// WaitForSelectorVisible is true if the
+	// element should also be visible.
+	WaitForSelectorVisible bool
+	// WaitForExpression is a JavaScript
+	// expression, or promise, to wait for
+	// until truthy, if not empty.
+	WaitForExpression string
+	HeaderHTML        string
+	FooterHTML        string
+	PaperWidth        float64
+	PaperHeight       float64
+	MarginTop         float64
+	MarginBottom      float64
+	MarginLeft        float64
+	MarginRight       float64
+	Landscape         bool
+	PageRanges        string
+	RpccBufferSize    int64
+	CustomHTTPHeaders map[string]string
+	Scale             float64
+	WaitForConnection bool
+	// Outline is true if the resulting PDF
+	// file should have bookmarks built from
+	// the h1 to h6 headings.
+	Outline bool
+	// TaggedPDF is true if the resulting PDF
+	// file should be tagged, i.e. have a
+	// logical structure, and an outline.
+	TaggedPDF bool
+	// AccessibilityCheck is true if the
+	// accessibility issues of the page should
+	// be reported as warnings.
+	AccessibilityCheck bool
+	// MetadataFromHTML is true if the title
+	// and the author of the page should be
+	// read for the metadata of the resulting
+	// PDF file.
+	MetadataFromHTML bool
+	// ResourceFailurePolicy tells which failed
+	// requests stop the conversion, unless
+	// they match the following options.
+	ResourceFailurePolicy string
+	// IgnoredStatusCodes are the HTTP status
+	// codes of the failed requests which do
+	// not stop the conversion.
+	IgnoredStatusCodes []int
+	// IgnoredResourceURLs are the URL patterns
+	// of the failed requests which do not stop
+	// the conversion.
+	IgnoredResourceURLs []string
+	// FatalResourceURLs are the URL patterns
+	// of the failed requests which always stop
+	// the conversion.
+	FatalResourceURLs []string
+	// JavaScriptErrorPolicy tells which
+	// JavaScript errors stop the conversion.
+	JavaScriptErrorPolicy string
+	// EmulatedMediaType is the CSS media
+	// type of the page (e.g. "screen"), if
+	// not empty.
+	EmulatedMediaType string
+	// PreferredColorScheme is the value of the
+	// prefers-color-scheme media feature of
+	// the page (e.g. "dark"), if not empty.
+	PreferredColorScheme string
+	// PrintBackground is true if the resulting
+	// PDF file should have the background
+	// graphics of the page.
+	PrintBackground bool
+	// OmitBackground is true if the default
+	// white background of the page should
+	// be transparent.
+	OmitBackground bool
+	// PreferCSSPageSize is true if the page
+	// size defined by the CSS @page rule, if
+	// any, should prevail over the paper size.
+	PreferCSSPageSize bool
+	// DisplayHeaderFooter is true if the
+	// header and footer should be rendered.
+	DisplayHeaderFooter bool
+	ViewportWidth       int64
+	ViewportHeight      int64
+	// DeviceScaleFactor is the ratio between
+	// the physical pixels and the CSS pixels
+	// (e.g. 2 for a high density screen).
+	DeviceScaleFactor float64
+	// Mobile is true if Google Chrome should
+	// emulate a mobile device, e.g. for the
+	// meta viewport tag.
+	Mobile bool
+	// Timezone is the IANA time zone name
+	// of the page (e.g. "Europe/Paris"),
+	// if not empty.
+	Timezone string
+	// Locale is the BCP 47 language tag of
+	// the page (e.g. "fr-FR"), if not empty.
+	Locale string
+	// UserAgent overrides the user agent
+	// of Google Chrome, if not empty.
+	UserAgent string
+	// Cookies are installed in the browser
+	// context before navigating.
+	Cookies []Cookie
+	// RequestFilters tell which requests of
+	// the page should be blocked: a request
+	// is blocked if any filter blocks it.
+	RequestFilters []RequestFilter
+	// EgressPolicy denies the requests of the
+	// page to the private addresses, if not nil.
+	EgressPolicy *egress.Policy
+	Admission    *admission.Controller
 }
 
 // DefaultChromePrinterOptions returns the default
@@ -59,40 +167,81 @@ type ChromePrinterOptions struct {
 func DefaultChromePrinterOptions(config conf.Config) ChromePrinterOptions {
 	const defaultHeaderFooterHTML string = "<html><head></head><body></body></html>"
 	return ChromePrinterOptions{
-		WaitTimeout:        config.DefaultWaitTimeout(),
-		WaitDelay:          0.0,
-		WaitJSRenderStatus: "",
-		HeaderHTML:         defaultHeaderFooterHTML,
-		FooterHTML:         defaultHeaderFooterHTML,
-		PaperWidth:         8.27,
-		PaperHeight:        11.7,
-		MarginTop:          1.0,
-		MarginBottom:       1.0,
-		MarginLeft:         1.0,
-		MarginRight:        1.0,
-		Landscape:          false,
-		PageRanges:         "",
-		RpccBufferSize:     config.DefaultGoogleChromeRpccBufferSize(),
-		CustomHTTPHeaders:  make(map[string]string),
-		Scale:              1.0,
-		MaxConnections:     config.GoogleChromeMaxConnections(),
-		WaitForConnection:  config.GoogleChromeWaitForConnection(),
+		WaitTimeout:            config.DefaultWaitTimeout(),
+		WaitDelay:              0.0,
+		WaitJSRenderStatus:     "",
+		WaitForSelector:        "",
+		WaitForSelectorVisible: false,
+		WaitForExpression:      "",
+		HeaderHTML:             defaultHeaderFooterHTML,
+		FooterHTML:             defaultHeaderFooterHTML,
+		PaperWidth:             8.27,
+		PaperHeight:            11.7,
+		MarginTop:              1.0,
+		MarginBottom:           1.0,
+		MarginLeft:             1.0,
+		MarginRight:            1.0,
+		Landscape:              false,
+		PageRanges:             "",
+		RpccBufferSize:         config.DefaultGoogleChromeRpccBufferSize(),
+		CustomHTTPHeaders:      make(map[string]string),
+		Scale:                  1.0,
+		WaitForConnection:      config.GoogleChromeWaitForConnection(),
+		Outline:                false,
+		TaggedPDF:              false,
+		AccessibilityCheck:     false,
+		MetadataFromHTML:       false,
+		ResourceFailurePolicy:  AllResourceFailurePolicy,
+		IgnoredStatusCodes:     nil,
+		IgnoredResourceURLs:    nil,
+		FatalResourceURLs:      nil,
+		JavaScriptErrorPolicy:  FailOnExceptionJavaScriptErrorPolicy,
+		EmulatedMediaType:      "",
+		PreferredColorScheme:   "",
+		PrintBackground:        true,
+		OmitBackground:         false,
+		PreferCSSPageSize:      false,
+		DisplayHeaderFooter:    true,
+		ViewportWidth:          800,
+		ViewportHeight:         600,
+		DeviceScaleFactor:      1.0,
+		Mobile:                 false,
+		Timezone:               "",
+		Locale:                 "",
+		UserAgent:              "",
+		Cookies:                nil,
+		RequestFilters:         []RequestFilter{ConfigRequestFilter(config)},
+		EgressPolicy:           egress.New(config),
+		Admission:              nil,
 	}
 }
 
-// nolint: gochecknoglobals
-var lockChrome = make(chan struct{}, 1)
-
-// nolint: gochecknoglobals
-var devtConnections int64
-
-func (p chromePrinter) Print(destination string) error {
+func (p chromePrinter) Print(parent context.Context, destination string) error {
 	const op string = "printer.chromePrinter.Print"
 	logOptions(p.logger, p.opts)
-	ctx, cancel := xcontext.WithTimeout(p.logger, p.opts.WaitTimeout+p.opts.WaitDelay)
+	if p.screenshotOpts != nil {
+		logOptions(p.logger, *p.screenshotOpts)
+	}
+	p.report.reset()
+	// the time spent in the queue does not
+	// count in the conversion timeout.
+	release, err := admit(parent, p.logger, p.opts.Admission, p.opts.WaitForConnection)
+	if err != nil {
+		return xcontext.MustHandleError(
+			parent,
+			xerror.New(op, err),
+		)
+	}
+	defer release()
+	ctx, cancel := xcontext.WithTimeout(parent, p.logger, p.opts.WaitTimeout+p.opts.WaitDelay)
 	defer cancel()
 	resolver := func() error {
-		devt, err := devtool.New("http://localhost:9222").Version(ctx)
+		instance, err := chrome.Acquire(ctx)
+		if err != nil {
+			return err
+		}
+		defer instance.Release()
+		devt, err := devtool.New(instance.Endpoint()).Version(ctx)
 		if err != nil {
 			return err
 		}
@@ -127,7 +276,7 @@ func (p chromePrinter) Print(destination string) error {
 			return err
 		}
 		// connect the client to the new target.
-		newTargetWsURL := fmt.Sprintf("ws://127.0.0.1:9222/devtools/page/%s", newTarget.TargetID)
+		newTargetWsURL := fmt.Sprintf("ws://127.0.0.1:%d/devtools/page/%s", instance.Port(), newTarget.TargetID)
 		newContextConn, err := rpcc.DialContext(
 			ctx,
 			newTargetWsURL,
@@ -163,6 +312,46 @@ func (p chromePrinter) Print(destination string) error {
 		if err := p.setCustomHTTPHeaders(ctx, targetClient); err != nil {
 			return err
 		}
+		// add cookies (if any).
+		if err := p.setCookies(ctx, targetClient); err != nil {
+			return err
+		}
+		// emulate the media (if any).
+		if err := p.emulateMedia(ctx, targetClient); err != nil {
+			return err
+		}
+		// emulate the time zone, the locale and
+		// the user agent (if any).
+		if err := p.emulateTimezone(ctx, targetClient); err != nil {
+			return err
+		}
+		if err := p.emulateLocale(ctx, targetClient); err != nil {
+			return err
+		}
+		if err := p.overrideUserAgent(ctx, targetClient); err != nil {
+			return err
+		}
+		// make the background transparent (if asked).
+		if err := p.omitBackground(ctx, targetClient); err != nil {
+			return err
+		}
+		// set the viewport before navigating, so
+		// that media queries match it.
+		if err := p.setViewport(
+			ctx,
+			targetClient,
+			p.opts.ViewportWidth,
+			p.opts.ViewportHeight,
+		); err != nil {
+			return err
+		}
+		// block the requests which do not pass
+		// the request filters (if any).
+		interception, err := p.interceptRequests(ctx, targetClient)
+		if err != nil {
+			return err
+		}
+		defer interception.close()
 		// listen for crashes
 		crashEvent, err := targetClient.Inspector.TargetCrashed(ctx)
 		if err != nil {
@@ -231,6 +420,24 @@ func (p chromePrinter) Print(destination string) error {
 					return err
 				}
 			}
+
+			// the following waits are bounded by
+			// the wait timeout too.
+			waits := []func(context.Context, *cdp.Client) error{p.waitForFonts}
+			if p.opts.WaitForSelector != "" {
+				waits = append(waits, p.waitForSelector)
+			}
+			if p.opts.WaitForExpression != "" {
+				waits = append(waits, p.waitForExpression)
+			}
+			for _, wait := range waits {
+				if err := wait(ctx, targetClient); err != nil {
+					if strings.Contains(err.Error(), "context canceled") {
+						return nil
+					}
+					return err
+				}
+			}
 			return nil
 		}
 
@@ -263,6 +470,13 @@ func (p chromePrinter) Print(destination string) error {
 					return err
 				}
 				p.logger.DebugOpf(op, "event 'exceptionThrown' received: %s", exception.ExceptionDetails)
+				p.report.addConsoleEntry(ConsoleEntry{
+					Type:    ExceptionConsoleEntryType,
+					Message: exception.ExceptionDetails.Error(),
+				})
+				if p.opts.JavaScriptErrorPolicy == IgnoreJavaScriptErrorPolicy {
+					continue
+				}
 				cancelOperation()
 				return xerror.Invalid(
 					op,
@@ -282,10 +496,25 @@ func (p chromePrinter) Print(destination string) error {
 					return err
 				}
 				p.logger.DebugOpf(op, "event 'consoleAPICalled' received: %s %s", log.Type, log.Args)
+				msg := consoleMessage(log.Args)
+				p.report.addConsoleEntry(ConsoleEntry{
+					Type:    log.Type,
+					Message: msg,
+				})
+				if log.Type != "error" || p.opts.JavaScriptErrorPolicy != FailOnConsoleErrorJavaScriptErrorPolicy {
+					continue
+				}
+				cancelOperation()
+				return xerror.Invalid(
+					op,
+					fmt.Sprintf("console.error: %s", msg),
+					nil,
+				)
 			}
 		}
 
 		requestURLs := make(map[network.RequestID]string)
+		mainDocumentRequests := make(map[network.RequestID]bool)
 		requestURLsMutex := sync.RWMutex{}
 
 		requestWillBeSentListener := func() error {
@@ -300,11 +529,20 @@ func (p chromePrinter) Print(destination string) error {
 				p.logger.DebugOpf(op, "event 'requestWillBeSent' received: %s %s", event.RequestID, event.Request.URL)
 				requestURLsMutex.Lock()
 				requestURLs[event.RequestID] = event.Request.URL
+				// the main frame shares the
+				// identifier of the target.
+				if event.Type == network.ResourceTypeDocument &&
+					event.FrameID != nil && string(*event.FrameID) == string(newTarget.TargetID) {
+					mainDocumentRequests[event.RequestID] = true
+				}
 				requestURLsMutex.Unlock()
 			}
 		}
 
 		requestErrorMessages := make(map[network.RequestID]string)
+		// the failed requests which do not
+		// stop the conversion.
+		ignoredErrorMessages := make(map[network.RequestID]string)
 		requestErrorMessagesMutex := sync.RWMutex{}
 
 		responseReceivedListener := func() error {
@@ -319,18 +557,34 @@ func (p chromePrinter) Print(destination string) error {
 
 				requestURLsMutex.RLock()
 				url := requestURLs[event.RequestID]
+				mainDocument := mainDocumentRequests[event.RequestID]
 				requestURLsMutex.RUnlock()
 				msg := fmt.Sprintf("%d %s", event.Response.Status, event.Response.StatusText)
 				p.logger.DebugOpf(op, "event 'responseReceived' received: %s: %s", url, msg)
 
+				// the content of any denied response may
+				// end up in the resulting file.
+				if event.Response.RemoteIPAddress != nil {
+					if err := interception.checkRemoteAddress(event.Response.URL, *event.Response.RemoteIPAddress); err != nil {
+						p.logger.DebugOpf(op, "denied response: %s: %s", url, err)
+						cancelOperation()
+						return err
+					}
+				}
+
 				if event.Response.Status < 400 {
 					continue
 				}
 
 				requestErrorMessagesMutex.Lock()
-				if value, ok := requestErrorMessages[event.RequestID]; !ok || value == "net::ERR_ABORTED" {
-					requestErrorMessages[event.RequestID] = msg
-					cancelOperation()
+				if p.isFatal(resourceFailure{url: url, status: event.Response.Status, mainDocument: mainDocument}) {
+					if value, ok := requestErrorMessages[event.RequestID]; !ok || value == "net::ERR_ABORTED" {
+						requestErrorMessages[event.RequestID] = msg
+						cancelOperation()
+					}
+				} else if _, ok := requestErrorMessages[event.RequestID]; !ok {
+					p.logger.DebugOpf(op, "ignoring failed request: %s: %s", url, msg)
+					ignoredErrorMessages[event.RequestID] = msg
 				}
 				requestErrorMessagesMutex.Unlock()
 			}
@@ -348,14 +602,29 @@ func (p chromePrinter) Print(destination string) error {
 
 				requestURLsMutex.RLock()
 				url := requestURLs[event.RequestID]
+				mainDocument := mainDocumentRequests[event.RequestID]
 				requestURLsMutex.RUnlock()
 				msg := fmt.Sprintf("%s", event.ErrorText)
 				p.logger.DebugOpf(op, "event 'loadingFailed' received: %s: %s", url, msg)
+				// a blocked request is already reported,
+				// but the main document is still fatal.
+				if interception.isBlocked(event.RequestID) && !mainDocument {
+					continue
+				}
 
 				requestErrorMessagesMutex.Lock()
-				if _, ok := requestErrorMessages[event.RequestID]; !ok {
-					requestErrorMessages[event.RequestID] = msg
-					cancelOperation()
+				_, fatal := requestErrorMessages[event.RequestID]
+				// a failed response has already
+				// been handled.
+				_, ignored := ignoredErrorMessages[event.RequestID]
+				if !fatal && !ignored {
+					if p.isFatal(resourceFailure{url: url, mainDocument: mainDocument}) {
+						requestErrorMessages[event.RequestID] = msg
+						cancelOperation()
+					} else {
+						p.logger.DebugOpf(op, "ignoring failed request: %s: %s", url, msg)
+						ignoredErrorMessages[event.RequestID] = msg
+					}
 				}
 				requestErrorMessagesMutex.Unlock()
 			}
@@ -390,6 +659,30 @@ func (p chromePrinter) Print(destination string) error {
 			)
 		}
 
+		// report the failed requests which
+		// did not stop the conversion.
+		for requestID, message := range ignoredErrorMessages {
+			p.report.addWarnings(Warning{
+				Code:    ResourceFailureWarningCode,
+				Message: message,
+				Element: requestURLs[requestID],
+			})
+		}
+
+		// report the accessibility issues (if asked).
+		if p.opts.AccessibilityCheck {
+			if err := p.checkAccessibility(ctx, targetClient); err != nil {
+				return err
+			}
+		}
+
+		// read the metadata of the page (if asked).
+		if p.opts.MetadataFromHTML {
+			if err := p.readMetadata(ctx, targetClient); err != nil {
+				return err
+			}
+		}
+
 		// listen for crashes
 		crashEvent, err = targetClient.Inspector.TargetCrashed(ctx)
 		if err != nil {
@@ -405,67 +698,10 @@ func (p chromePrinter) Print(destination string) error {
 			defer cancel()
 			cancelOperation = cancel
 
-			printToPdfArgs := page.NewPrintToPDFArgs().
-				SetTransferMode("ReturnAsStream").
-				SetPaperWidth(p.opts.PaperWidth).
-				SetPaperHeight(p.opts.PaperHeight).
-				SetMarginTop(p.opts.MarginTop).
-				SetMarginBottom(p.opts.MarginBottom).
-				SetMarginLeft(p.opts.MarginLeft).
-				SetMarginRight(p.opts.MarginRight).
-				SetLandscape(p.opts.Landscape).
-				SetDisplayHeaderFooter(true).
-				SetHeaderTemplate(p.opts.HeaderHTML).
-				SetFooterTemplate(p.opts.FooterHTML).
-				SetPrintBackground(true).
-				SetScale(p.opts.Scale)
-			if p.opts.PageRanges != "" {
-				printToPdfArgs.SetPageRanges(p.opts.PageRanges)
+			if p.screenshotOpts != nil {
+				return p.captureScreenshot(ctx, targetClient, destination)
 			}
-			// printToPDF the page to PDF.
-			p.logger.DebugOp(op, "starting PrintToPDF")
-			printToPDF, err := targetClient.Page.PrintToPDF(
-				ctx,
-				printToPdfArgs,
-			)
-			if err != nil {
-				// find a way to check it in the handlers?
-				if strings.Contains(err.Error(), "Page range syntax error") {
-					return xerror.Invalid(
-						op,
-						fmt.Sprintf("'%s' is not a valid Google Chrome page ranges", p.opts.PageRanges),
-						err,
-					)
-				}
-				if strings.Contains(err.Error(), "rpcc: message too large") {
-					return xerror.Invalid(
-						op,
-						fmt.Sprintf(
-							"'%d' bytes are not enough: increase the Google Chrome rpcc buffer size (up to 100 MB)",
-							p.opts.RpccBufferSize,
-						),
-						err,
-					)
-				}
-				return err
-			}
-
-			p.logger.DebugOp(op, "streaming PDF from Chrome")
-			streamReader := targetClient.NewIOStreamReader(ctx, *printToPDF.Stream)
-			reader := bufio.NewReader(streamReader)
-			file, err := os.OpenFile(destination, os.O_CREATE|os.O_WRONLY, 0600)
-			if err != nil {
-				return err
-			}
-			if _, err = reader.WriteTo(file); err != nil {
-				return err
-			}
-			if err = file.Close(); err != nil {
-				return err
-			}
-			p.logger.DebugOp(op, "streaming complete")
-
-			return nil
+			return p.printToPDF(ctx, newContextConn, targetClient, destination)
 		}
 
 		if err := runBatch(
@@ -478,51 +714,114 @@ func (p chromePrinter) Print(destination string) error {
 
 		return nil
 	}
-	if devtConnections+1 < p.opts.MaxConnections {
-		p.logger.DebugOp(op, "skipping lock acquisition...")
-		devtConnections++
-		err := resolver()
-		devtConnections--
-		if err != nil {
-			return xcontext.MustHandleError(
-				ctx,
-				xerror.New(op, err),
-			)
-		}
-		return nil
-	}
-	if devtConnections >= p.opts.MaxConnections && !p.opts.WaitForConnection {
-		return xerror.Invalid(
-			op,
-			"no available connections",
-			nil,
+	if err := resolver(); err != nil {
+		return xcontext.MustHandleError(
+			ctx,
+			xerror.New(op, err),
 		)
 	}
-	p.logger.DebugOp(op, "waiting lock to be acquired...")
-	select {
-	case lockChrome <- struct{}{}:
-		// lock acquired.
-		p.logger.DebugOp(op, "lock acquired")
-		devtConnections++
-		err := resolver()
-		devtConnections--
-		<-lockChrome // we release the lock.
+	return nil
+}
+
+/*
+printToPDFArgs adds to page.PrintToPDFArgs
+the arguments of Page.printToPDF that the
+cdp package does not know yet.
+*/
+type printToPDFArgs struct {
+	*page.PrintToPDFArgs
+	GenerateDocumentOutline *bool `json:"generateDocumentOutline,omitempty"`
+	GenerateTaggedPDF       *bool `json:"generateTaggedPDF,omitempty"`
+}
+
+func (p chromePrinter) printToPDF(ctx context.Context, conn *rpcc.Conn, client *cdp.Client, destination string) error {
+	const op string = "printer.chromePrinter.printToPDF"
+	resolver := func() error {
+		printToPdfArgs := page.NewPrintToPDFArgs().
+			SetTransferMode("ReturnAsStream").
+			SetPaperWidth(p.opts.PaperWidth).
+			SetPaperHeight(p.opts.PaperHeight).
+			SetMarginTop(p.opts.MarginTop).
+			SetMarginBottom(p.opts.MarginBottom).
+			SetMarginLeft(p.opts.MarginLeft).
+			SetMarginRight(p.opts.MarginRight).
+			SetLandscape(p.opts.Landscape).
+			SetDisplayHeaderFooter(p.opts.DisplayHeaderFooter).
+			SetHeaderTemplate(p.opts.HeaderHTML).
+			SetFooterTemplate(p.opts.FooterHTML).
+			SetPrintBackground(p.opts.PrintBackground).
+			SetPreferCSSPageSize(p.opts.PreferCSSPageSize).
+			SetScale(p.opts.Scale)
+		if p.opts.PageRanges != "" {
+			printToPdfArgs.SetPageRanges(p.opts.PageRanges)
+		}
+		args := printToPDFArgs{PrintToPDFArgs: printToPdfArgs}
+		// a tagged PDF file should also
+		// have an outline.
+		if p.opts.Outline || p.opts.TaggedPDF {
+			outline := true
+			args.GenerateDocumentOutline = &outline
+		}
+		if p.opts.TaggedPDF {
+			args.GenerateTaggedPDF = &p.opts.TaggedPDF
+		}
+		// printToPDF the page to PDF.
+		p.logger.DebugOp(op, "starting PrintToPDF")
+		printToPDF := new(page.PrintToPDFReply)
+		if err := rpcc.Invoke(ctx, "Page.printToPDF", &args, printToPDF, conn); err != nil {
+			// find a way to check it in the handlers?
+			if strings.Contains(err.Error(), "Page range syntax error") {
+				return xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' is not a valid Google Chrome page ranges", p.opts.PageRanges),
+					err,
+				)
+			}
+			if strings.Contains(err.Error(), "rpcc: message too large") {
+				return xerror.Invalid(
+					op,
+					fmt.Sprintf(
+						"'%d' bytes are not enough: increase the Google Chrome rpcc buffer size (up to 100 MB)",
+						p.opts.RpccBufferSize,
+					),
+					err,
+				)
+			}
+			return err
+		}
+
+		p.logger.DebugOp(op, "streaming PDF from Chrome")
+		streamReader := client.NewIOStreamReader(ctx, *printToPDF.Stream)
+		reader := bufio.NewReader(streamReader)
+		file, err := os.OpenFile(destination, os.O_CREATE|os.O_WRONLY, 0600)
 		if err != nil {
-			return xcontext.MustHandleError(
-				ctx,
-				xerror.New(op, err),
-			)
+			return err
 		}
+		if _, err = reader.WriteTo(file); err != nil {
+			return err
+		}
+		if err = file.Close(); err != nil {
+			return err
+		}
+		p.logger.DebugOp(op, "streaming complete")
 		return nil
-	case <-ctx.Done():
-		// failed to acquire lock before
-		// deadline.
-		p.logger.DebugOp(op, "failed to acquire lock before context.Context deadline")
-		return xcontext.MustHandleError(
-			ctx,
-			ctx.Err(),
-		)
 	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+// Warnings returns the warnings
+// of the last conversion.
+func (p chromePrinter) Warnings() []Warning {
+	return p.report.allWarnings()
+}
+
+// ConsoleEntries returns the console
+// entries of the last conversion.
+func (p chromePrinter) ConsoleEntries() []ConsoleEntry {
+	return p.report.allConsoleEntries()
 }
 
 func (p chromePrinter) enableEvents(ctx context.Context, client *cdp.Client) error {
@@ -666,6 +965,8 @@ func runBatch(ctx context.Context, fn ...func() error) error {
 // Compile-time checks to ensure type implements desired interfaces.
 var (
 	_ = Printer(new(chromePrinter))
+	_ = Reporter(new(chromePrinter))
+	_ = MetadataReader(new(chromePrinter))
 )
 
 func Eval(ctx context.Context, c *cdp.Client, expr string, out interface{}) error {
@@ -705,6 +1006,16 @@ func Wait(ctx context.Context, c *cdp.Client, expr string) error {
 	})
 }
 
+func WaitPromise(ctx context.Context, c *cdp.Client, expr string) error {
+	return poll(ctx, func() (bool, error) {
+		var ok bool
+		if err := EvalPromise(ctx, c, expr, &ok); err != nil {
+			return false, err
+		}
+		return ok, nil
+	})
+}
+
 func poll(ctx context.Context, fn func() (bool, error)) error {
 	t := time.NewTimer(1 * time.Second)
 	if !t.Stop() {
@@ -0,0 +1,53 @@
+package printer
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/mafredri/cdp/protocol/runtime"
+)
+
+const (
+	// IgnoreJavaScriptErrorPolicy never stops
+	// the conversion on JavaScript errors.
+	IgnoreJavaScriptErrorPolicy string = "ignore"
+	// FailOnExceptionJavaScriptErrorPolicy stops
+	// the conversion on an uncaught exception.
+	FailOnExceptionJavaScriptErrorPolicy string = "failOnException"
+	// FailOnConsoleErrorJavaScriptErrorPolicy stops
+	// the conversion on an uncaught exception or
+	// on a call to console.error.
+	FailOnConsoleErrorJavaScriptErrorPolicy string = "failOnConsoleError"
+	// ExceptionConsoleEntryType is the type
+	// of the ConsoleEntry of an exception.
+	ExceptionConsoleEntryType string = "exception"
+)
+
+// JavaScriptErrorPolicies returns the
+// available JavaScript error policies.
+func JavaScriptErrorPolicies() []string {
+	return []string{
+		IgnoreJavaScriptErrorPolicy,
+		FailOnExceptionJavaScriptErrorPolicy,
+		FailOnConsoleErrorJavaScriptErrorPolicy,
+	}
+}
+
+/*
+consoleMessage returns the message of a console
+call, i.e. its arguments separated by spaces,
+like the Google Chrome console displays them.
+*/
+func consoleMessage(args []runtime.RemoteObject) string {
+	values := make([]string, len(args))
+	for i, arg := range args {
+		// strings should not be quoted.
+		var value string
+		if arg.Type == "string" && json.Unmarshal(arg.Value, &value) == nil {
+			values[i] = value
+			continue
+		}
+		values[i] = arg.String()
+	}
+	return strings.Join(values, " ")
+}
@@ -0,0 +1,21 @@
+package printer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mafredri/cdp/protocol/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsoleMessage(t *testing.T) {
+	description := "Error: foo"
+	args := []runtime.RemoteObject{
+		{Type: "string", Value: json.RawMessage(`"total:"`)},
+		{Type: "number", Value: json.RawMessage(`42`)},
+		{Type: "object", Description: &description},
+		{Type: "undefined"},
+	}
+	assert.Equal(t, "total: 42 Error: foo undefined", consoleMessage(args))
+	assert.Equal(t, "", consoleMessage(nil))
+}
@@ -0,0 +1,129 @@
+package printer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mafredri/cdp"
+	"github.com/mafredri/cdp/protocol/network"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+)
+
+const (
+	// StrictCookieSameSite is the
+	// "Strict" SameSite attribute.
+	StrictCookieSameSite string = "Strict"
+	// LaxCookieSameSite is the
+	// "Lax" SameSite attribute.
+	LaxCookieSameSite string = "Lax"
+	// NoneCookieSameSite is the
+	// "None" SameSite attribute.
+	NoneCookieSameSite string = "None"
+)
+
+// CookieSameSites returns the
+// available SameSite attributes.
+func CookieSameSites() []string {
+	return []string{
+		StrictCookieSameSite,
+		LaxCookieSameSite,
+		NoneCookieSameSite,
+	}
+}
+
+// Cookie is a cookie installed in the
+// browser context before navigating.
+type Cookie struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// Domain is the domain of the cookie.
+	// If empty, the cookie belongs to the
+	// host of the URL to convert.
+	Domain   string `json:"domain"`
+	Path     string `json:"path"`
+	Secure   bool   `json:"secure"`
+	HTTPOnly bool   `json:"httpOnly"`
+	SameSite string `json:"sameSite"`
+	// Expires is the expiration date of
+	// the cookie as a Unix timestamp in
+	// seconds, or 0 for a session cookie.
+	Expires float64 `json:"expires"`
+}
+
+/*
+String returns a representation of the
+Cookie without its value, so that it is
+never logged.
+*/
+func (c Cookie) String() string {
+	value := ""
+	if c.Value != "" {
+		value = "***"
+	}
+	return fmt.Sprintf(
+		"{Name:%s Value:%s Domain:%s Path:%s Secure:%t HTTPOnly:%t SameSite:%s Expires:%v}",
+		c.Name,
+		value,
+		c.Domain,
+		c.Path,
+		c.Secure,
+		c.HTTPOnly,
+		c.SameSite,
+		c.Expires,
+	)
+}
+
+/*
+cookieParams converts the cookies of the
+options to their Chrome DevTools Protocol
+representation.
+*/
+func (p chromePrinter) cookieParams() []network.CookieParam {
+	params := make([]network.CookieParam, len(p.opts.Cookies))
+	for i, cookie := range p.opts.Cookies {
+		cookie := cookie
+		param := network.CookieParam{
+			Name:     cookie.Name,
+			Value:    cookie.Value,
+			Secure:   &cookie.Secure,
+			HTTPOnly: &cookie.HTTPOnly,
+			SameSite: network.CookieSameSite(cookie.SameSite),
+			Expires:  network.TimeSinceEpoch(cookie.Expires),
+		}
+		if cookie.Domain != "" {
+			param.Domain = &cookie.Domain
+		} else {
+			url := p.url
+			param.URL = &url
+		}
+		if cookie.Path != "" {
+			param.Path = &cookie.Path
+		}
+		params[i] = param
+	}
+	return params
+}
+
+/*
+setCookies installs the cookies of the options
+in the browser context of the target, so that
+they are sent with the requests of the page
+and its redirects.
+*/
+func (p chromePrinter) setCookies(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.setCookies"
+	if len(p.opts.Cookies) == 0 {
+		p.logger.DebugOp(op, "skipping cookies as none have been provided...")
+		return nil
+	}
+	for _, cookie := range p.opts.Cookies {
+		// the values are usually session identifiers:
+		// they are masked in the logged options too.
+		p.logger.DebugOpf(op, "set cookie '%s' for domain '%s'", cookie.Name, cookie.Domain)
+	}
+	// should always be called after client.Network.Enable.
+	if err := client.Network.SetCookies(ctx, network.NewSetCookiesArgs(p.cookieParams())); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
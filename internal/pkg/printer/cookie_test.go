@@ -0,0 +1,45 @@
+package printer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestCookieParams(t *testing.T) {
+	opts := DefaultChromePrinterOptions(conf.DefaultConfig())
+	opts.Cookies = []Cookie{
+		{Name: "session", Value: "foo", Domain: ".example.com", Path: "/app", Secure: true, HTTPOnly: true, SameSite: LaxCookieSameSite},
+		{Name: "theme", Value: "dark", Expires: 1893456000},
+	}
+	p := chromePrinter{logger: test.DebugLogger(), url: "https://example.com/dashboard", opts: opts}
+	params := p.cookieParams()
+	assert.Len(t, params, 2)
+	// should have the given domain and path.
+	assert.Equal(t, ".example.com", *params[0].Domain)
+	assert.Equal(t, "/app", *params[0].Path)
+	assert.Nil(t, params[0].URL)
+	assert.True(t, *params[0].Secure)
+	assert.True(t, *params[0].HTTPOnly)
+	assert.Equal(t, LaxCookieSameSite, string(params[0].SameSite))
+	// should belong to the URL to convert
+	// as there is no domain.
+	assert.Nil(t, params[1].Domain)
+	assert.Nil(t, params[1].Path)
+	assert.Equal(t, "https://example.com/dashboard", *params[1].URL)
+	assert.False(t, *params[1].Secure)
+	assert.Equal(t, 1893456000.0, float64(params[1].Expires))
+}
+
+func TestCookieString(t *testing.T) {
+	opts := DefaultChromePrinterOptions(conf.DefaultConfig())
+	opts.Cookies = []Cookie{{Name: "session", Value: "foo", Domain: ".example.com"}}
+	// should not contain the value of the
+	// cookie, as logged by logOptions.
+	result := fmt.Sprintf("%+v", opts)
+	assert.NotContains(t, result, "foo")
+	assert.Contains(t, result, "Name:session Value:***")
+}
@@ -0,0 +1,187 @@
+package printer
+
+import (
+	"context"
+
+	"github.com/mafredri/cdp"
+	"github.com/mafredri/cdp/protocol/dom"
+	"github.com/mafredri/cdp/protocol/emulation"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+)
+
+const (
+	// PrintMediaType is the
+	// print CSS media type.
+	PrintMediaType string = "print"
+	// ScreenMediaType is the
+	// screen CSS media type.
+	ScreenMediaType string = "screen"
+	// LightColorScheme is the light
+	// value of prefers-color-scheme.
+	LightColorScheme string = "light"
+	// DarkColorScheme is the dark
+	// value of prefers-color-scheme.
+	DarkColorScheme string = "dark"
+)
+
+// MediaTypes returns the CSS
+// media types Google Chrome
+// may emulate.
+func MediaTypes() []string {
+	return []string{
+		PrintMediaType,
+		ScreenMediaType,
+	}
+}
+
+// ColorSchemes returns the values of
+// prefers-color-scheme Google Chrome
+// may emulate.
+func ColorSchemes() []string {
+	return []string{
+		LightColorScheme,
+		DarkColorScheme,
+	}
+}
+
+/*
+emulateMedia sets the CSS media type and the
+prefers-color-scheme media feature of the page,
+if the options ask for them.
+*/
+func (p chromePrinter) emulateMedia(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.emulateMedia"
+	if p.opts.EmulatedMediaType == "" && p.opts.PreferredColorScheme == "" {
+		p.logger.DebugOp(op, "skipping media emulation as none has been asked...")
+		return nil
+	}
+	args := emulation.NewSetEmulatedMediaArgs()
+	if p.opts.EmulatedMediaType != "" {
+		p.logger.DebugOpf(op, "emulating media type '%s'...", p.opts.EmulatedMediaType)
+		args.SetMedia(p.opts.EmulatedMediaType)
+	}
+	if p.opts.PreferredColorScheme != "" {
+		p.logger.DebugOpf(op, "emulating color scheme '%s'...", p.opts.PreferredColorScheme)
+		args.SetFeatures([]emulation.MediaFeature{
+			{Name: "prefers-color-scheme", Value: p.opts.PreferredColorScheme},
+		})
+	}
+	if err := client.Emulation.SetEmulatedMedia(ctx, args); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+emulateTimezone sets the time zone of the
+page, e.g. for Date and Intl.DateTimeFormat,
+if the options ask for it.
+*/
+func (p chromePrinter) emulateTimezone(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.emulateTimezone"
+	if p.opts.Timezone == "" {
+		return nil
+	}
+	p.logger.DebugOpf(op, "emulating time zone '%s'...", p.opts.Timezone)
+	args := emulation.NewSetTimezoneOverrideArgs(p.opts.Timezone)
+	if err := client.Emulation.SetTimezoneOverride(ctx, args); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+emulateLocale sets the locale of the page,
+e.g. for Intl and toLocaleString, if the
+options ask for it.
+*/
+func (p chromePrinter) emulateLocale(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.emulateLocale"
+	if p.opts.Locale == "" {
+		return nil
+	}
+	p.logger.DebugOpf(op, "emulating locale '%s'...", p.opts.Locale)
+	args := emulation.NewSetLocaleOverrideArgs().SetLocale(p.opts.Locale)
+	if err := client.Emulation.SetLocaleOverride(ctx, args); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+overrideUserAgent sets the user agent and the
+Accept-Language header of the requests, and
+the navigator properties of the page, if the
+options ask for a user agent or a locale.
+
+Without a user agent in the options, the one
+of Google Chrome is kept.
+*/
+func (p chromePrinter) overrideUserAgent(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.overrideUserAgent"
+	if p.opts.UserAgent == "" && p.opts.Locale == "" {
+		return nil
+	}
+	resolver := func() error {
+		userAgent := p.opts.UserAgent
+		if userAgent == "" {
+			version, err := client.Browser.GetVersion(ctx)
+			if err != nil {
+				return err
+			}
+			userAgent = version.UserAgent
+		}
+		p.logger.DebugOpf(op, "overriding user agent with '%s'...", userAgent)
+		args := emulation.NewSetUserAgentOverrideArgs(userAgent)
+		if p.opts.Locale != "" {
+			args.SetAcceptLanguage(p.opts.Locale)
+		}
+		return client.Emulation.SetUserAgentOverride(ctx, args)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+setViewport sets the size of the viewport,
+with the device scale factor and the mobile
+emulation of the options.
+*/
+func (p chromePrinter) setViewport(ctx context.Context, client *cdp.Client, width, height int64) error {
+	const op string = "printer.chromePrinter.setViewport"
+	p.logger.DebugOpf(
+		op,
+		"setting viewport to '%dx%d' (device scale factor: %.2f, mobile: %t)...",
+		width,
+		height,
+		p.opts.DeviceScaleFactor,
+		p.opts.Mobile,
+	)
+	args := emulation.NewSetDeviceMetricsOverrideArgs(int(width), int(height), p.opts.DeviceScaleFactor, p.opts.Mobile)
+	if err := client.Emulation.SetDeviceMetricsOverride(ctx, args); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+omitBackground makes the default white
+background of the page transparent, if
+the options ask for it.
+*/
+func (p chromePrinter) omitBackground(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.omitBackground"
+	if !p.opts.OmitBackground {
+		return nil
+	}
+	p.logger.DebugOp(op, "omitting the default background...")
+	transparent := 0.0
+	args := emulation.NewSetDefaultBackgroundColorOverrideArgs().
+		SetColor(dom.RGBA{R: 0, G: 0, B: 0, A: &transparent})
+	if err := client.Emulation.SetDefaultBackgroundColorOverride(ctx, args); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package printer
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// AllResourceFailurePolicy stops the
+	// conversion on any failed request.
+	AllResourceFailurePolicy string = "all"
+	// MainDocumentResourceFailurePolicy stops
+	// the conversion only if the request of
+	// the main document fails.
+	MainDocumentResourceFailurePolicy string = "mainDocument"
+	// ResourceFailureWarningCode is the code of
+	// the warning for an ignored failed request.
+	ResourceFailureWarningCode string = "resource-failure"
+)
+
+// ResourceFailurePolicies returns the
+// available resource failure policies.
+func ResourceFailurePolicies() []string {
+	return []string{
+		AllResourceFailurePolicy,
+		MainDocumentResourceFailurePolicy,
+	}
+}
+
+// resourceFailure is a failed request
+// of a Google Chrome conversion.
+type resourceFailure struct {
+	url string
+	// status is 0 if the
+	// request has no response.
+	status       int
+	mainDocument bool
+}
+
+/*
+isFatal returns true if the given failed
+request should stop the conversion.
+
+A failure of the main document is always
+fatal. Otherwise, the fatal URL patterns
+come first, then the ignored URL patterns
+and status codes, and finally the policy.
+*/
+func (p chromePrinter) isFatal(failure resourceFailure) bool {
+	if failure.mainDocument {
+		return true
+	}
+	for _, pattern := range p.opts.FatalResourceURLs {
+		if matchURLPattern(pattern, failure.url) {
+			return true
+		}
+	}
+	for _, pattern := range p.opts.IgnoredResourceURLs {
+		if matchURLPattern(pattern, failure.url) {
+			return false
+		}
+	}
+	for _, status := range p.opts.IgnoredStatusCodes {
+		if failure.status != 0 && failure.status == status {
+			return false
+		}
+	}
+	return p.opts.ResourceFailurePolicy != MainDocumentResourceFailurePolicy
+}
+
+/*
+matchURLPattern returns true if the given URL
+matches the given glob pattern, where "*"
+matches any sequence of characters, including
+"/", and "?" matches any single character.
+*/
+func matchURLPattern(pattern, url string) bool {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, `.*`)
+	expr = strings.ReplaceAll(expr, `\?`, `.`)
+	matched, err := regexp.MatchString("^"+expr+"$", url)
+	return err == nil && matched
+}
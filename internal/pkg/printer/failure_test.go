@@ -0,0 +1,50 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestIsFatal(t *testing.T) {
+	opts := DefaultChromePrinterOptions(conf.DefaultConfig())
+	p := chromePrinter{logger: test.DebugLogger(), opts: opts}
+	favicon := resourceFailure{url: "https://example.com/favicon.ico", status: 404}
+	// should be fatal by default.
+	assert.True(t, p.isFatal(favicon))
+	assert.True(t, p.isFatal(resourceFailure{url: "https://example.com/"}))
+	// should not be fatal as the status
+	// code is ignored.
+	p.opts.IgnoredStatusCodes = []int{404, 410}
+	assert.False(t, p.isFatal(favicon))
+	assert.True(t, p.isFatal(resourceFailure{url: favicon.url}))
+	// should be fatal as the main
+	// document always is.
+	assert.True(t, p.isFatal(resourceFailure{url: "https://example.com/", status: 404, mainDocument: true}))
+	// should not be fatal as the URL
+	// is ignored.
+	p.opts = opts
+	p.opts.IgnoredResourceURLs = []string{"https://*.example.com/*", "*/favicon.ico"}
+	assert.False(t, p.isFatal(favicon))
+	assert.False(t, p.isFatal(resourceFailure{url: "https://tracker.example.com/pixel.gif?id=1"}))
+	assert.True(t, p.isFatal(resourceFailure{url: "https://example.com/logo.png"}))
+	// should only be fatal for the
+	// main document and the fatal URLs.
+	p.opts = opts
+	p.opts.ResourceFailurePolicy = MainDocumentResourceFailurePolicy
+	p.opts.FatalResourceURLs = []string{"*.css"}
+	assert.False(t, p.isFatal(favicon))
+	assert.True(t, p.isFatal(resourceFailure{url: "https://example.com/style.css", status: 404}))
+	assert.True(t, p.isFatal(resourceFailure{url: "https://example.com/", mainDocument: true}))
+}
+
+func TestMatchURLPattern(t *testing.T) {
+	assert.True(t, matchURLPattern("*", "https://example.com/a/b"))
+	assert.True(t, matchURLPattern("https://example.com/*.png", "https://example.com/img/logo.png"))
+	assert.True(t, matchURLPattern("https://example.com/?.png", "https://example.com/a.png"))
+	assert.False(t, matchURLPattern("https://example.com/?.png", "https://example.com/ab.png"))
+	assert.False(t, matchURLPattern("https://example.com/*", "https://example.org/"))
+	assert.False(t, matchURLPattern("https://example.com/(.*)", "https://example.com/a"))
+}
@@ -14,5 +14,6 @@ func NewHTMLPrinter(logger xlog.Logger, fpath string, opts ChromePrinterOptions)
 		logger: logger,
 		url:    URL,
 		opts:   opts,
+		report: &report{},
 	}
 }
@@ -1,6 +1,7 @@
 package printer
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -25,7 +26,7 @@ func TestHTMLPrinter(t *testing.T) {
 	opts = DefaultChromePrinterOptions(config)
 	p = NewHTMLPrinter(logger, fpath, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
@@ -34,7 +35,7 @@ func TestHTMLPrinter(t *testing.T) {
 	opts.WaitDelay = 0.5
 	p = NewHTMLPrinter(logger, fpath, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
@@ -43,17 +44,33 @@ func TestHTMLPrinter(t *testing.T) {
 	opts.PageRanges = "1"
 	p = NewHTMLPrinter(logger, fpath, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
+	// options with a tagged PDF and an
+	// accessibility check.
+	opts = DefaultChromePrinterOptions(config)
+	opts.TaggedPDF = true
+	opts.AccessibilityCheck = true
+	p = NewHTMLPrinter(logger, fpath, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(context.Background(), dest)
+	assert.Nil(t, err)
+	warnings := p.(Reporter).Warnings()
+	assert.Len(t, warnings, 2)
+	for _, warning := range warnings {
+		assert.Equal(t, MissingAltWarningCode, warning.Code)
+	}
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
 	// should not be OK as options have
 	// a wrong page ranges.
 	opts = DefaultChromePrinterOptions(config)
 	opts.PageRanges = "foo"
 	p = NewHTMLPrinter(logger, fpath, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	test.AssertError(t, err)
 	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
 	err = os.RemoveAll(dest)
@@ -64,7 +81,7 @@ func TestHTMLPrinter(t *testing.T) {
 	opts.WaitTimeout = 0.0
 	p = NewHTMLPrinter(logger, fpath, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	test.AssertError(t, err)
 	assert.Equal(t, xerror.TimeoutCode, xerror.Code(err))
 	err = os.RemoveAll(dest)
@@ -0,0 +1,402 @@
+package printer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/mafredri/cdp"
+	"github.com/mafredri/cdp/protocol/fetch"
+	"github.com/mafredri/cdp/protocol/network"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/egress"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+)
+
+// BlockedRequestWarningCode is the code of the
+// warning for a request blocked by a filter.
+const BlockedRequestWarningCode string = "blocked-request"
+
+// RequestFilter tells which requests
+// Google Chrome should block.
+type RequestFilter struct {
+	// AllowedURLs are the URL patterns of the
+	// only requests which are allowed, if not
+	// empty.
+	AllowedURLs []string
+	// DeniedURLs are the URL patterns of
+	// the requests which are blocked.
+	DeniedURLs []string
+	// BlockedResourceTypes are the resource
+	// types (e.g. "image") which are blocked.
+	BlockedResourceTypes []string
+	// BlockPrivateNetworks is true if the requests
+	// to the loopback, private and link-local
+	// addresses are blocked.
+	BlockPrivateNetworks bool
+}
+
+// ConfigRequestFilter returns the request
+// filter from the configuration.
+func ConfigRequestFilter(config conf.Config) RequestFilter {
+	return RequestFilter{
+		AllowedURLs:          config.GoogleChromeAllowedURLs(),
+		DeniedURLs:           config.GoogleChromeDeniedURLs(),
+		BlockedResourceTypes: config.GoogleChromeBlockedResourceTypes(),
+		BlockPrivateNetworks: config.GoogleChromeBlockPrivateNetworks(),
+	}
+}
+
+// IsEmpty returns true if the
+// filter blocks no request.
+func (f RequestFilter) IsEmpty() bool {
+	return len(f.AllowedURLs) == 0 &&
+		len(f.DeniedURLs) == 0 &&
+		len(f.BlockedResourceTypes) == 0 &&
+		!f.BlockPrivateNetworks
+}
+
+/*
+blockReason returns why the filter blocks the
+given request, or an empty string if it does
+not.
+
+The private function is only called if the
+filter blocks the private networks, as it may
+resolve the host of the URL.
+*/
+func (f RequestFilter) blockReason(url string, resourceType network.ResourceType, private func() bool) string {
+	for _, pattern := range f.DeniedURLs {
+		if matchURLPattern(pattern, url) {
+			return fmt.Sprintf("the URL matches the denied pattern '%s'", pattern)
+		}
+	}
+	if len(f.AllowedURLs) > 0 {
+		allowed := false
+		for _, pattern := range f.AllowedURLs {
+			if matchURLPattern(pattern, url) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return "the URL matches no allowed pattern"
+		}
+	}
+	for _, blocked := range f.BlockedResourceTypes {
+		if cdpResourceTypes[blocked] == resourceType {
+			return fmt.Sprintf("the resource type '%s' is blocked", blocked)
+		}
+	}
+	if f.BlockPrivateNetworks && private() {
+		return "the host resolves to a private network address"
+	}
+	return ""
+}
+
+// cdpResourceTypes maps the resource types
+// to their Chrome DevTools Protocol names.
+var cdpResourceTypes = map[string]network.ResourceType{
+	conf.ImageResourceType:      network.ResourceTypeImage,
+	conf.FontResourceType:       network.ResourceTypeFont,
+	conf.MediaResourceType:      network.ResourceTypeMedia,
+	conf.ScriptResourceType:     network.ResourceTypeScript,
+	conf.StylesheetResourceType: network.ResourceTypeStylesheet,
+}
+
+/*
+isPrivateHost returns true if the given host
+is, or resolves to, a private IP address.
+
+As Google Chrome resolves the host again, a
+DNS record with a short TTL may still point
+it elsewhere afterwards: checkRemoteAddress
+catches it once the response is received.
+*/
+func isPrivateHost(ctx context.Context, host string) bool {
+	if host == "" || strings.EqualFold(host, "localhost") {
+		return true
+	}
+	if ip := net.ParseIP(strings.Trim(host, "[]")); ip != nil {
+		return egress.IsPrivateIP(ip)
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		// Google Chrome will fail to
+		// resolve the host too.
+		return false
+	}
+	for _, addr := range addrs {
+		if egress.IsPrivateIP(addr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+interception pauses the requests of a
+target and blocks the ones which do not
+pass the request filters.
+*/
+type interception struct {
+	logger  xlog.Logger
+	filters []RequestFilter
+	report  *report
+	stream  fetch.RequestPausedClient
+	// blocked are the network identifiers
+	// of the blocked requests.
+	blocked map[network.RequestID]bool
+	// private caches the result of
+	// isPrivateHost by host.
+	private map[string]bool
+	egress  *egress.Policy
+	// denied caches the reason why the
+	// egress policy denies a host, by host.
+	denied map[string]string
+	mu     sync.RWMutex
+	done   chan struct{}
+}
+
+/*
+interceptRequests enables the Fetch domain on
+the target if a request filter is not empty or
+if there is an egress policy, and handles the
+paused requests until close is called.
+
+It returns a nil *interception otherwise.
+*/
+func (p chromePrinter) interceptRequests(ctx context.Context, client *cdp.Client) (*interception, error) {
+	const op string = "printer.chromePrinter.interceptRequests"
+	var filters []RequestFilter
+	for _, filter := range p.opts.RequestFilters {
+		if !filter.IsEmpty() {
+			filters = append(filters, filter)
+		}
+	}
+	if len(filters) == 0 && p.opts.EgressPolicy == nil {
+		p.logger.DebugOp(op, "skipping request interception as no filter nor egress policy has been provided...")
+		return nil, nil
+	}
+	resolver := func() (*interception, error) {
+		stream, err := client.Fetch.RequestPaused(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pattern := "*"
+		args := fetch.NewEnableArgs().SetPatterns([]fetch.RequestPattern{
+			{URLPattern: &pattern, RequestStage: fetch.RequestStageRequest},
+		})
+		if err := client.Fetch.Enable(ctx, args); err != nil {
+			stream.Close() // nolint: errcheck
+			return nil, err
+		}
+		i := &interception{
+			logger:  p.logger,
+			filters: filters,
+			report:  p.report,
+			stream:  stream,
+			blocked: make(map[network.RequestID]bool),
+			private: make(map[string]bool),
+			egress:  p.opts.EgressPolicy,
+			denied:  make(map[string]string),
+			done:    make(chan struct{}),
+		}
+		go i.listen(ctx, client)
+		return i, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+listen continues or fails each paused request,
+as long as the stream is open.
+
+A request left paused would hang the page, so
+the requests are always answered, even if a
+filter cannot decide.
+*/
+func (i *interception) listen(ctx context.Context, client *cdp.Client) {
+	const op string = "printer.interception.listen"
+	defer close(i.done)
+	for {
+		event, err := i.stream.Recv()
+		if err != nil {
+			return
+		}
+		reason := i.blockReason(ctx, event.Request.URL, event.ResourceType)
+		if reason == "" {
+			if err := client.Fetch.ContinueRequest(ctx, fetch.NewContinueRequestArgs(event.RequestID)); err != nil {
+				i.logger.DebugOpf(op, "failed to continue request '%s': %s", event.Request.URL, err)
+			}
+			continue
+		}
+		i.logger.DebugOpf(op, "blocking request '%s': %s", event.Request.URL, reason)
+		// the request is marked as blocked before
+		// failing it, so that the failure listener
+		// knows about it.
+		if event.NetworkID != nil {
+			i.mu.Lock()
+			i.blocked[network.RequestID(*event.NetworkID)] = true
+			i.mu.Unlock()
+		}
+		i.report.addWarnings(Warning{
+			Code:    BlockedRequestWarningCode,
+			Message: reason,
+			Element: event.Request.URL,
+		})
+		args := fetch.NewFailRequestArgs(event.RequestID, network.ErrorReasonBlockedByClient)
+		if err := client.Fetch.FailRequest(ctx, args); err != nil {
+			i.logger.DebugOpf(op, "failed to block request '%s': %s", event.Request.URL, err)
+		}
+	}
+}
+
+/*
+blockReason returns why the egress policy or a
+filter blocks the given request, or an empty
+string if none does.
+
+Only the network requests are filtered: the
+files of the conversion and the data URLs
+are always allowed.
+*/
+func (i *interception) blockReason(ctx context.Context, rawURL string, resourceType network.ResourceType) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "the URL is not valid"
+	}
+	switch u.Scheme {
+	case "http", "https", "ws", "wss", "ftp":
+	default:
+		return ""
+	}
+	private := func() bool {
+		host := u.Hostname()
+		i.mu.RLock()
+		result, ok := i.private[host]
+		i.mu.RUnlock()
+		if ok {
+			return result
+		}
+		result = isPrivateHost(ctx, host)
+		i.mu.Lock()
+		i.private[host] = result
+		i.mu.Unlock()
+		return result
+	}
+	if reason := i.deniedReason(ctx, u.Hostname()); reason != "" {
+		return reason
+	}
+	for _, filter := range i.filters {
+		if reason := filter.blockReason(rawURL, resourceType, private); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+/*
+deniedReason returns why the egress policy
+denies the given host, or an empty string if
+it does not.
+*/
+func (i *interception) deniedReason(ctx context.Context, host string) string {
+	if i.egress == nil {
+		return ""
+	}
+	i.mu.RLock()
+	reason, ok := i.denied[host]
+	i.mu.RUnlock()
+	if ok {
+		return reason
+	}
+	if err := i.egress.CheckHost(ctx, host); err != nil {
+		reason = xerror.Message(err)
+	}
+	i.mu.Lock()
+	i.denied[host] = reason
+	i.mu.Unlock()
+	return reason
+}
+
+/*
+checkRemoteAddress returns an error with
+xerror.ForbiddenCode if the given request has
+reached a denied address, i.e. one the egress
+policy denies, or a private one while a filter
+blocks the private networks.
+
+As Google Chrome resolves the hosts again after
+blockReason, a DNS record with a short TTL may
+point a host elsewhere in between: only the
+address it has actually reached is reliable.
+
+It is safe to call on a nil *interception.
+*/
+func (i *interception) checkRemoteAddress(rawURL, remoteIPAddress string) error {
+	const op string = "printer.interception.checkRemoteAddress"
+	if i == nil {
+		return nil
+	}
+	ip := net.ParseIP(strings.Trim(remoteIPAddress, "[]"))
+	if ip == nil {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return xerror.Invalid(op, fmt.Sprintf("'%s' is not a valid URL", rawURL), err)
+	}
+	if err := i.egress.CheckRemoteIP(u.Hostname(), ip); err != nil {
+		return xerror.New(op, err)
+	}
+	if !egress.IsPrivateIP(ip) {
+		return nil
+	}
+	for _, filter := range i.filters {
+		if filter.BlockPrivateNetworks {
+			return xerror.Forbidden(
+				op,
+				fmt.Sprintf("the host '%s' has been reached at the private network address '%s'", u.Hostname(), ip),
+				nil,
+			)
+		}
+	}
+	return nil
+}
+
+/*
+isBlocked returns true if the request with
+the given network identifier has been blocked.
+
+It is safe to call on a nil *interception.
+*/
+func (i *interception) isBlocked(requestID network.RequestID) bool {
+	if i == nil {
+		return false
+	}
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.blocked[requestID]
+}
+
+/*
+close stops handling the paused requests.
+
+It is safe to call on a nil *interception.
+*/
+func (i *interception) close() {
+	if i == nil {
+		return
+	}
+	i.stream.Close() // nolint: errcheck
+	<-i.done
+}
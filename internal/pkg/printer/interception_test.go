@@ -0,0 +1,106 @@
+package printer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mafredri/cdp/protocol/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/egress"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestRequestFilterBlockReason(t *testing.T) {
+	public := func() bool { return false }
+	private := func() bool { return true }
+	// should block nothing.
+	filter := RequestFilter{}
+	assert.True(t, filter.IsEmpty())
+	assert.Empty(t, filter.blockReason("http://127.0.0.1/", network.ResourceTypeImage, private))
+	// should block the denied URLs.
+	filter = RequestFilter{DeniedURLs: []string{"https://tracker.example.com/*"}}
+	assert.False(t, filter.IsEmpty())
+	assert.NotEmpty(t, filter.blockReason("https://tracker.example.com/pixel.gif", network.ResourceTypeImage, public))
+	assert.Empty(t, filter.blockReason("https://example.com/logo.png", network.ResourceTypeImage, public))
+	// should only allow the allowed URLs.
+	filter = RequestFilter{AllowedURLs: []string{"https://*.example.com/*"}}
+	assert.Empty(t, filter.blockReason("https://cdn.example.com/app.js", network.ResourceTypeScript, public))
+	assert.NotEmpty(t, filter.blockReason("https://example.org/app.js", network.ResourceTypeScript, public))
+	// should block the resource types.
+	filter = RequestFilter{BlockedResourceTypes: []string{conf.FontResourceType, conf.MediaResourceType}}
+	assert.NotEmpty(t, filter.blockReason("https://example.com/font.woff2", network.ResourceTypeFont, public))
+	assert.NotEmpty(t, filter.blockReason("https://example.com/video.mp4", network.ResourceTypeMedia, public))
+	assert.Empty(t, filter.blockReason("https://example.com/logo.png", network.ResourceTypeImage, public))
+	// should block the private networks.
+	filter = RequestFilter{BlockPrivateNetworks: true}
+	assert.NotEmpty(t, filter.blockReason("http://10.0.0.1/", network.ResourceTypeDocument, private))
+	assert.Empty(t, filter.blockReason("https://example.com/", network.ResourceTypeDocument, public))
+}
+
+func TestIsPrivateHost(t *testing.T) {
+	ctx := context.Background()
+	assert.True(t, isPrivateHost(ctx, "localhost"))
+	assert.True(t, isPrivateHost(ctx, "127.0.0.1"))
+	assert.True(t, isPrivateHost(ctx, "10.1.2.3"))
+	assert.True(t, isPrivateHost(ctx, "192.168.0.1"))
+	assert.True(t, isPrivateHost(ctx, "169.254.169.254"))
+	assert.True(t, isPrivateHost(ctx, "[::1]"))
+	assert.True(t, isPrivateHost(ctx, "fd00::1"))
+	assert.True(t, isPrivateHost(ctx, "0.0.0.0"))
+	assert.False(t, isPrivateHost(ctx, "93.184.216.34"))
+	assert.False(t, isPrivateHost(ctx, "2606:2800:220:1:248:1893:25c8:1946"))
+}
+
+func TestInterceptionBlockReason(t *testing.T) {
+	i := &interception{
+		logger: test.DebugLogger(),
+		filters: []RequestFilter{
+			{DeniedURLs: []string{"*"}},
+			{BlockPrivateNetworks: true},
+		},
+		private: make(map[string]bool),
+	}
+	ctx := context.Background()
+	// should not filter the files and
+	// the data URLs.
+	assert.Empty(t, i.blockReason(ctx, "file:///tmp/index.html", network.ResourceTypeDocument))
+	assert.Empty(t, i.blockReason(ctx, "data:image/png;base64,iVBORw0KGgo=", network.ResourceTypeImage))
+	// should be blocked by the first filter.
+	assert.NotEmpty(t, i.blockReason(ctx, "https://example.com/", network.ResourceTypeDocument))
+	// should be blocked by the second filter.
+	i.filters = i.filters[1:]
+	assert.NotEmpty(t, i.blockReason(ctx, "http://127.0.0.1:3000/", network.ResourceTypeXHR))
+	assert.True(t, i.private["127.0.0.1"])
+	// should be safe to call on a nil
+	// interception.
+	var nilInterception *interception
+	assert.False(t, nilInterception.isBlocked(network.RequestID("1")))
+	assert.Nil(t, nilInterception.checkRemoteAddress("http://example.com/", "127.0.0.1"))
+	nilInterception.close()
+}
+
+func TestInterceptionCheckRemoteAddress(t *testing.T) {
+	i := &interception{
+		logger: test.DebugLogger(),
+		egress: egress.New(conf.DefaultConfig()),
+	}
+	// should be OK as the address is public
+	// or unknown.
+	assert.Nil(t, i.checkRemoteAddress("https://example.com/", "93.184.216.34"))
+	assert.Nil(t, i.checkRemoteAddress("https://example.com/", ""))
+	// should not be OK as the host has been
+	// rebound to a private address.
+	err := i.checkRemoteAddress("https://example.com/", "[::1]")
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.ForbiddenCode, xerror.Code(err))
+	// should not be OK as a filter
+	// blocks the private networks.
+	i.egress = nil
+	assert.Nil(t, i.checkRemoteAddress("https://example.com/", "10.1.2.3"))
+	i.filters = []RequestFilter{{BlockPrivateNetworks: true}}
+	err = i.checkRemoteAddress("https://example.com/", "10.1.2.3")
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.ForbiddenCode, xerror.Code(err))
+}
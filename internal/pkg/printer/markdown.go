@@ -48,6 +48,7 @@ func NewMarkdownPrinter(logger xlog.Logger, fpath string, opts ChromePrinterOpti
 		logger: logger,
 		url:    URL,
 		opts:   opts,
+		report: &report{},
 	}, nil
 }
 
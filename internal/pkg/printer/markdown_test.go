@@ -1,6 +1,7 @@
 package printer
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -26,7 +27,7 @@ func TestMarkdownPrinter(t *testing.T) {
 	p, err = NewMarkdownPrinter(logger, fpath, opts)
 	assert.Nil(t, err)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
@@ -36,7 +37,7 @@ func TestMarkdownPrinter(t *testing.T) {
 	p, err = NewMarkdownPrinter(logger, fpath, opts)
 	assert.Nil(t, err)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
@@ -46,7 +47,7 @@ func TestMarkdownPrinter(t *testing.T) {
 	p, err = NewMarkdownPrinter(logger, fpath, opts)
 	assert.Nil(t, err)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
@@ -57,7 +58,7 @@ func TestMarkdownPrinter(t *testing.T) {
 	p, err = NewMarkdownPrinter(logger, fpath, opts)
 	assert.Nil(t, err)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	test.AssertError(t, err)
 	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
 	err = os.RemoveAll(dest)
@@ -69,7 +70,7 @@ func TestMarkdownPrinter(t *testing.T) {
 	p, err = NewMarkdownPrinter(logger, fpath, opts)
 	assert.Nil(t, err)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	test.AssertError(t, err)
 	assert.Equal(t, xerror.TimeoutCode, xerror.Code(err))
 	err = os.RemoveAll(dest)
@@ -2,17 +2,17 @@ package printer
 
 import (
 	"context"
-	"sort"
 
+	"github.com/thecodingmachine/gotenberg/internal/pkg/admission"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xcontext"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xexec"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xpdf"
 )
 
 type mergePrinter struct {
-	ctx    context.Context
 	logger xlog.Logger
 	fpaths []string
 	opts   MergePrinterOptions
@@ -22,6 +22,11 @@ type mergePrinter struct {
 // merge Printer behaviour.
 type MergePrinterOptions struct {
 	WaitTimeout float64
+	Backend     string
+	// Bookmarks are the titles of the
+	// bookmarks of the merged files (if any).
+	Bookmarks []string
+	Admission *admission.Controller
 }
 
 // DefaultMergePrinterOptions returns the default
@@ -29,6 +34,9 @@ type MergePrinterOptions struct {
 func DefaultMergePrinterOptions(config conf.Config) MergePrinterOptions {
 	return MergePrinterOptions{
 		WaitTimeout: config.DefaultWaitTimeout(),
+		Backend:     config.MergeBackend(),
+		Bookmarks:   nil,
+		Admission:   nil,
 	}
 }
 
@@ -42,37 +50,90 @@ func NewMergePrinter(logger xlog.Logger, fpaths []string, opts MergePrinterOptio
 	}
 }
 
-func (p mergePrinter) Print(destination string) error {
+func (p mergePrinter) Print(parent context.Context, destination string) error {
 	const op string = "printer.mergePrinter.Print"
-	/*
-		context.Context may be providen from
-		an officePrinter which needs to merge
-		its result files.
-	*/
-	if p.ctx == nil {
-		logOptions(p.logger, p.opts)
-		ctx, cancel := xcontext.WithTimeout(p.logger, p.opts.WaitTimeout)
-		defer cancel()
-		p.ctx = ctx
+	logOptions(p.logger, p.opts)
+	// the time spent in the queue does not
+	// count in the conversion timeout.
+	release, err := admit(parent, p.logger, p.opts.Admission, true)
+	if err != nil {
+		return xcontext.MustHandleError(
+			parent,
+			xerror.New(op, err),
+		)
 	}
-	// see https://github.com/thecodingmachine/gotenberg/issues/139.
-	sort.Strings(p.fpaths)
+	defer release()
+	ctx, cancel := xcontext.WithTimeout(parent, p.logger, p.opts.WaitTimeout)
+	defer cancel()
+	resolver := func() error {
+		return p.merge(ctx, destination)
+	}
+	if err := resolver(); err != nil {
+		return xcontext.MustHandleError(
+			ctx,
+			xerror.New(op, err),
+		)
+	}
+	return nil
+}
+
+/*
+merge merges the PDFs without waiting for
+a conversion slot.
+
+It is also used by the officePrinter which
+needs to merge its result files.
+*/
+func (p mergePrinter) merge(ctx context.Context, destination string) error {
+	const op string = "printer.mergePrinter.merge"
 	p.logger.DebugOpf(op, "merging '%v'...", p.fpaths)
 	resolver := func() error {
-		var args []string
-		args = append(args, p.fpaths...)
-		args = append(args, "cat", "output", destination)
-		return xexec.Run(p.ctx, p.logger, "pdftk", args...)
+		if p.opts.Backend == conf.PDFcpuMergeBackend {
+			if err := xpdf.Merge(ctx, p.logger, p.fpaths, destination); err != nil {
+				return err
+			}
+		} else {
+			var args []string
+			args = append(args, p.fpaths...)
+			args = append(args, "cat", "output", destination)
+			if err := xexec.Run(ctx, p.logger, "pdftk", args...); err != nil {
+				return err
+			}
+		}
+		if len(p.opts.Bookmarks) == 0 {
+			return nil
+		}
+		return addFileBookmarks(ctx, p.logger, p.fpaths, p.opts.Bookmarks, destination)
 	}
 	if err := resolver(); err != nil {
 		return xcontext.MustHandleError(
-			p.ctx,
+			ctx,
 			xerror.New(op, err),
 		)
 	}
 	return nil
 }
 
+/*
+addFileBookmarks replaces the outline of the
+given merged PDF file with one bookmark per
+source PDF file.
+*/
+func addFileBookmarks(ctx context.Context, logger xlog.Logger, fpaths, titles []string, destination string) error {
+	const op string = "printer.addFileBookmarks"
+	resolver := func() error {
+		bookmarks, err := xpdf.FileBookmarks(fpaths, titles)
+		if err != nil {
+			return err
+		}
+		return xpdf.SetBookmarks(ctx, logger, destination, bookmarks)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
 // Compile-time checks to ensure type implements desired interfaces.
 var (
 	_ = Printer(new(mergePrinter))
@@ -1,13 +1,18 @@
 package printer
 
 import (
+	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/admission"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xpdf"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xtime"
 	"github.com/thecodingmachine/gotenberg/test"
 )
 
@@ -25,19 +30,70 @@ func TestMergePrinter(t *testing.T) {
 	opts = DefaultMergePrinterOptions(config)
 	p = NewMergePrinter(logger, fpaths, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
+	// pdfcpu backend.
+	opts = DefaultMergePrinterOptions(config)
+	opts.Backend = conf.PDFcpuMergeBackend
+	p = NewMergePrinter(logger, fpaths, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(context.Background(), dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// bookmarks.
+	opts = DefaultMergePrinterOptions(config)
+	opts.Backend = conf.PDFcpuMergeBackend
+	opts.Bookmarks = []string{"Gotenberg", "Gotenberg bis"}
+	p = NewMergePrinter(logger, fpaths, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(context.Background(), dest)
+	assert.Nil(t, err)
+	bookmarks, err := xpdf.Bookmarks(dest)
+	assert.Nil(t, err)
+	assert.Len(t, bookmarks, 2)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
 	// should not be OK as context.Context
 	// should timeout.
 	opts = DefaultMergePrinterOptions(config)
 	opts.WaitTimeout = 0.0
 	p = NewMergePrinter(logger, fpaths, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	test.AssertError(t, err)
 	assert.Equal(t, xerror.TimeoutCode, xerror.Code(err))
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
+	// should be OK as the time spent in the
+	// queue does not count in the timeout.
+	opts = DefaultMergePrinterOptions(config)
+	opts.Backend = conf.PDFcpuMergeBackend
+	opts.WaitTimeout = 1.0
+	opts.Admission = admission.New(1, 1, 0)
+	err = opts.Admission.Acquire(context.Background())
+	assert.Nil(t, err)
+	time.AfterFunc(xtime.Duration(1.5), opts.Admission.Release)
+	p = NewMergePrinter(logger, fpaths, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(context.Background(), dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as the conversion
+	// waited too long in the queue.
+	opts = DefaultMergePrinterOptions(config)
+	opts.WaitTimeout = 0.1
+	opts.Admission = admission.New(1, 1, 0.5)
+	err = opts.Admission.Acquire(context.Background())
+	assert.Nil(t, err)
+	p = NewMergePrinter(logger, fpaths, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(context.Background(), dest)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.UnavailableCode, xerror.Code(err))
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
 }
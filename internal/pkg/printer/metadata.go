@@ -0,0 +1,66 @@
+package printer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mafredri/cdp"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xpdf"
+)
+
+/*
+MetadataReader is a Printer which may read
+the metadata of the document of its last
+conversion.
+*/
+type MetadataReader interface {
+	DocumentMetadata() xpdf.Metadata
+}
+
+/*
+documentMetadataExpression returns the title
+and the author of the rendered page, so that
+the ones set by JavaScript count too.
+*/
+const documentMetadataExpression string = `(() => {
+	const author = document.querySelector('meta[name="author" i]');
+	return {
+		title: document.title,
+		author: author ? author.getAttribute('content') || '' : ''
+	};
+})()`
+
+/*
+readMetadata reads the title and
+the author of the loaded page.
+*/
+func (p chromePrinter) readMetadata(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.readMetadata"
+	resolver := func() error {
+		var result struct {
+			Title  string `json:"title"`
+			Author string `json:"author"`
+		}
+		if err := Eval(ctx, client, documentMetadataExpression, &result); err != nil {
+			return err
+		}
+		metadata := xpdf.Metadata{
+			Title:  strings.TrimSpace(result.Title),
+			Author: strings.TrimSpace(result.Author),
+		}
+		p.logger.DebugOpf(op, "metadata read: %+v", metadata)
+		p.report.setMetadata(metadata)
+		return nil
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+// DocumentMetadata returns the metadata of
+// the page of the last conversion, if read.
+func (p chromePrinter) DocumentMetadata() xpdf.Metadata {
+	return p.report.documentMetadata()
+}
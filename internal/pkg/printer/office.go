@@ -5,15 +5,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
 	"strings"
 
 	"github.com/phayes/freeport"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/admission"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xcontext"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xexec"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xpdf"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xrand"
 )
 
@@ -29,15 +30,29 @@ type OfficePrinterOptions struct {
 	WaitTimeout float64
 	Landscape   bool
 	PageRanges  string
+	// PDFFormat is the PDF/A format LibreOffice
+	// exports (if any). It requires a single file,
+	// as merging PDF/A files does not produce a
+	// PDF/A file.
+	PDFFormat    string
+	MergeBackend string
+	// Bookmarks are the titles of the
+	// bookmarks of the merged files (if any).
+	Bookmarks []string
+	Admission *admission.Controller
 }
 
 // DefaultOfficePrinterOptions returns the default
 // Office Printer options.
 func DefaultOfficePrinterOptions(config conf.Config) OfficePrinterOptions {
 	return OfficePrinterOptions{
-		WaitTimeout: config.DefaultWaitTimeout(),
-		Landscape:   false,
-		PageRanges:  "",
+		WaitTimeout:  config.DefaultWaitTimeout(),
+		Landscape:    false,
+		PageRanges:   "",
+		PDFFormat:    "",
+		MergeBackend: config.MergeBackend(),
+		Bookmarks:    nil,
+		Admission:    nil,
 	}
 }
 
@@ -51,14 +66,29 @@ func NewOfficePrinter(logger xlog.Logger, fpaths []string, opts OfficePrinterOpt
 	}
 }
 
-func (p officePrinter) Print(destination string) error {
+func (p officePrinter) Print(parent context.Context, destination string) error {
 	const op string = "printer.officePrinter.Print"
 	logOptions(p.logger, p.opts)
-	ctx, cancel := xcontext.WithTimeout(p.logger, p.opts.WaitTimeout)
+	if p.opts.PDFFormat != "" && len(p.fpaths) > 1 {
+		return xerror.Invalid(
+			op,
+			fmt.Sprintf("'%s' cannot be satisfied when merging several files", p.opts.PDFFormat),
+			nil,
+		)
+	}
+	// the time spent in the queue does not
+	// count in the conversion timeout.
+	release, err := admit(parent, p.logger, p.opts.Admission, true)
+	if err != nil {
+		return xcontext.MustHandleError(
+			parent,
+			xerror.New(op, err),
+		)
+	}
+	defer release()
+	ctx, cancel := xcontext.WithTimeout(parent, p.logger, p.opts.WaitTimeout)
 	defer cancel()
 	resolver := func() error {
-		// see https://github.com/thecodingmachine/gotenberg/issues/139.
-		sort.Strings(p.fpaths)
 		fpaths := make([]string, len(p.fpaths))
 		dirPath := filepath.Dir(destination)
 		for i, fpath := range p.fpaths {
@@ -73,14 +103,28 @@ func (p officePrinter) Print(destination string) error {
 		}
 		if len(fpaths) == 1 {
 			p.logger.DebugOp(op, "only one PDF created, nothing to merge")
+			if p.opts.PDFFormat != "" {
+				if err := xpdf.CheckPDFA(fpaths[0], p.opts.PDFFormat); err != nil {
+					return err
+				}
+			}
 			return os.Rename(fpaths[0], destination)
 		}
 		m := mergePrinter{
 			logger: p.logger,
-			ctx:    ctx,
 			fpaths: fpaths,
+			opts: MergePrinterOptions{
+				Backend: p.opts.MergeBackend,
+			},
 		}
-		return m.Print(destination)
+		// the conversion has already been admitted.
+		if err := m.merge(ctx, destination); err != nil {
+			return err
+		}
+		if len(p.opts.Bookmarks) == 0 {
+			return nil
+		}
+		return addFileBookmarks(ctx, p.logger, fpaths, p.opts.Bookmarks, destination)
 	}
 	if err := resolver(); err != nil {
 		return xcontext.MustHandleError(
@@ -113,6 +157,9 @@ func (p officePrinter) unoconv(ctx context.Context, fpath, destination string) e
 		if p.opts.PageRanges != "" {
 			args = append(args, "--export", fmt.Sprintf("PageRange=%s", p.opts.PageRanges))
 		}
+		if p.opts.PDFFormat != "" {
+			args = append(args, "--export", fmt.Sprintf("SelectPdfVersion=%s", selectPdfVersion(p.opts.PDFFormat)))
+		}
 		args = append(args, "--output", destination, fpath)
 		err = xexec.Run(ctx, p.logger, "unoconv", args...)
 		// always remove user profile folders created by LibreOffice.
@@ -137,6 +184,24 @@ func (p officePrinter) unoconv(ctx context.Context, fpath, destination string) e
 	return nil
 }
 
+/*
+selectPdfVersion returns the value of the
+LibreOffice PDF export filter option
+"SelectPdfVersion" for the given PDF format.
+*/
+func selectPdfVersion(format string) string {
+	switch format {
+	case xpdf.PDFA1b:
+		return "1"
+	case xpdf.PDFA2b:
+		return "2"
+	case xpdf.PDFA3b:
+		return "3"
+	default:
+		return "0"
+	}
+}
+
 func cleanupUserProfile(logger xlog.Logger, dirName string) {
 	const op = "printer.cleanupUserProfile"
 	path := fmt.Sprintf("/tmp/%s", dirName)
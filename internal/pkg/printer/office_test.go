@@ -1,6 +1,7 @@
 package printer
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xpdf"
 	"github.com/thecodingmachine/gotenberg/test"
 )
 
@@ -25,7 +27,7 @@ func TestOfficePrinter(t *testing.T) {
 	opts = DefaultOfficePrinterOptions(config)
 	p = NewOfficePrinter(logger, fpaths, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
@@ -33,16 +35,36 @@ func TestOfficePrinter(t *testing.T) {
 	opts = DefaultOfficePrinterOptions(config)
 	p = NewOfficePrinter(logger, []string{fpaths[0]}, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
+	// PDF/A format using one file.
+	opts = DefaultOfficePrinterOptions(config)
+	opts.PDFFormat = xpdf.PDFA1b
+	p = NewOfficePrinter(logger, []string{fpaths[0]}, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(context.Background(), dest)
+	assert.Nil(t, err)
+	err = xpdf.CheckPDFA(dest, xpdf.PDFA1b)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as merging PDF/A
+	// files does not produce a PDF/A file.
+	opts = DefaultOfficePrinterOptions(config)
+	opts.PDFFormat = xpdf.PDFA2b
+	p = NewOfficePrinter(logger, fpaths, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(context.Background(), dest)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
 	// options with landscape.
 	opts = DefaultOfficePrinterOptions(config)
 	opts.Landscape = true
 	p = NewOfficePrinter(logger, fpaths, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
@@ -51,7 +73,7 @@ func TestOfficePrinter(t *testing.T) {
 	opts.PageRanges = "1-1"
 	p = NewOfficePrinter(logger, []string{fpaths[0]}, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
@@ -61,7 +83,7 @@ func TestOfficePrinter(t *testing.T) {
 	opts.PageRanges = "foo"
 	p = NewOfficePrinter(logger, []string{fpaths[0]}, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	test.AssertError(t, err)
 	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
 	err = os.RemoveAll(dest)
@@ -72,7 +94,7 @@ func TestOfficePrinter(t *testing.T) {
 	opts.WaitTimeout = 0.0
 	p = NewOfficePrinter(logger, fpaths, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	test.AssertError(t, err)
 	assert.Equal(t, xerror.TimeoutCode, xerror.Code(err))
 	err = os.RemoveAll(dest)
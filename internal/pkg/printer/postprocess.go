@@ -0,0 +1,93 @@
+package printer
+
+import (
+	"context"
+
+	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xcontext"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xpdf"
+)
+
+// PostProcessOptions helps customizing the
+// post-processing of a resulting PDF file.
+type PostProcessOptions struct {
+	WaitTimeout float64
+	// Overlays are applied in the given order,
+	// e.g. a watermark then a stamp.
+	Overlays []xpdf.Overlay
+	// Metadata also update the XMP
+	// metadata of a PDF/A file.
+	Metadata xpdf.Metadata
+	// Attachments are embedded after the
+	// conversion, as LibreOffice would drop
+	// them.
+	Attachments []xpdf.Attachment
+	// Encrypt is true if the resulting PDF
+	// file should be encrypted. The encryption
+	// is always the last step.
+	Encrypt    bool
+	Encryption xpdf.Encryption
+}
+
+// DefaultPostProcessOptions returns the default
+// post-processing options.
+func DefaultPostProcessOptions(config conf.Config) PostProcessOptions {
+	return PostProcessOptions{
+		WaitTimeout: config.DefaultWaitTimeout(),
+		Overlays:    nil,
+		Metadata:    xpdf.Metadata{},
+		Attachments: nil,
+		Encrypt:     false,
+		Encryption: xpdf.Encryption{
+			AllowPrinting: true,
+			AllowCopying:  true,
+		},
+	}
+}
+
+/*
+PostProcess modifies in place the PDF file
+created by a Printer according to the given
+options. It does nothing if there is nothing
+to do.
+*/
+func PostProcess(parent context.Context, logger xlog.Logger, fpath string, opts PostProcessOptions) error {
+	const op string = "printer.PostProcess"
+	if len(opts.Overlays) == 0 && opts.Metadata.IsEmpty() &&
+		len(opts.Attachments) == 0 && !opts.Encrypt {
+		return nil
+	}
+	logOptions(logger, opts)
+	ctx, cancel := xcontext.WithTimeout(parent, logger, opts.WaitTimeout)
+	defer cancel()
+	resolver := func() error {
+		for _, overlay := range opts.Overlays {
+			if err := xpdf.AddOverlay(ctx, logger, fpath, overlay); err != nil {
+				return err
+			}
+		}
+		if !opts.Metadata.IsEmpty() {
+			if err := xpdf.SetMetadata(ctx, logger, fpath, opts.Metadata); err != nil {
+				return err
+			}
+		}
+		if len(opts.Attachments) > 0 {
+			if err := xpdf.Attach(ctx, logger, fpath, opts.Attachments); err != nil {
+				return err
+			}
+		}
+		if !opts.Encrypt {
+			return nil
+		}
+		return xpdf.Encrypt(ctx, logger, fpath, opts.Encryption)
+	}
+	if err := resolver(); err != nil {
+		return xcontext.MustHandleError(
+			ctx,
+			xerror.New(op, err),
+		)
+	}
+	return nil
+}
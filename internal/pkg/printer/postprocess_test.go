@@ -0,0 +1,109 @@
+package printer
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xpdf"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestPostProcess(t *testing.T) {
+	var (
+		logger xlog.Logger = test.DebugLogger()
+		config conf.Config = conf.DefaultConfig()
+		fpath  string      = test.MergeFpaths(t)[0]
+		opts   PostProcessOptions
+		dest   string
+		err    error
+	)
+	copyFile := func() string {
+		b, err := ioutil.ReadFile(fpath)
+		require.Nil(t, err)
+		dest := test.GenerateDestination()
+		err = ioutil.WriteFile(dest, b, 0600)
+		require.Nil(t, err)
+		return dest
+	}
+	// default options: nothing to do.
+	opts = DefaultPostProcessOptions(config)
+	opts.WaitTimeout = 0.0
+	dest = copyFile()
+	err = PostProcess(context.Background(), logger, dest, opts)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// watermark and stamp.
+	opts = DefaultPostProcessOptions(config)
+	opts.Overlays = []xpdf.Overlay{
+		{Text: "DRAFT", Opacity: 0.5, Rotation: 45},
+		{Text: "CONFIDENTIAL", OnTop: true, Opacity: 1},
+	}
+	dest = copyFile()
+	err = PostProcess(context.Background(), logger, dest, opts)
+	assert.Nil(t, err)
+	ok, err := api.HasWatermarksFile(dest, nil)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// metadata.
+	opts = DefaultPostProcessOptions(config)
+	opts.Metadata = xpdf.Metadata{Title: "Gutenberg"}
+	dest = copyFile()
+	err = PostProcess(context.Background(), logger, dest, opts)
+	assert.Nil(t, err)
+	pdf, err := api.ReadContextFile(dest)
+	assert.Nil(t, err)
+	assert.Equal(t, "Gutenberg", pdf.Title)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// attachments.
+	opts = DefaultPostProcessOptions(config)
+	opts.Attachments = []xpdf.Attachment{
+		{Fpath: test.AttachmentFpaths(t)[1], Relationship: xpdf.SupplementRelationship},
+	}
+	dest = copyFile()
+	err = PostProcess(context.Background(), logger, dest, opts)
+	assert.Nil(t, err)
+	f, err := os.Open(dest)
+	require.Nil(t, err)
+	attachments, err := api.Attachments(f, nil)
+	assert.Nil(t, err)
+	assert.Len(t, attachments, 1)
+	err = f.Close()
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// encryption.
+	opts = DefaultPostProcessOptions(config)
+	opts.Encrypt = true
+	opts.Encryption.UserPassword = "foo"
+	opts.Encryption.OwnerPassword = "bar"
+	assert.NotContains(t, fmt.Sprintf("%+v", opts), "foo")
+	dest = copyFile()
+	err = PostProcess(context.Background(), logger, dest, opts)
+	assert.Nil(t, err)
+	_, err = api.PageCountFile(dest)
+	assert.NotNil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as context.Context
+	// should timeout.
+	opts.WaitTimeout = 0.0
+	dest = copyFile()
+	err = PostProcess(context.Background(), logger, dest, opts)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.TimeoutCode, xerror.Code(err))
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+}
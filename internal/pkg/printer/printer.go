@@ -1,16 +1,53 @@
 package printer
 
 import (
+	"context"
+
+	"github.com/thecodingmachine/gotenberg/internal/pkg/admission"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
 )
 
-// Printer is a type that can create a PDF file from a source.
-// The source is defined in the underlying implementation.
+/*
+Printer is a type that can create a PDF file from a source.
+The source is defined in the underlying implementation.
+
+The conversion stops as soon as the given
+context.Context is done (e.g. the client
+has disconnected).
+*/
 type Printer interface {
-	Print(destination string) error
+	Print(ctx context.Context, destination string) error
 }
 
 func logOptions(logger xlog.Logger, opts interface{}) {
 	const op string = "printer.logOptions"
 	logger.DebugOpf(op, "options: %+v", opts)
 }
+
+/*
+admit waits for a conversion slot of given
+admission.Controller, or fails directly if
+there is none and it should not wait.
+
+It returns the function for releasing the slot.
+A nil admission.Controller admits everything.
+*/
+func admit(ctx context.Context, logger xlog.Logger, a *admission.Controller, wait bool) (func(), error) {
+	const op string = "printer.admit"
+	if a == nil {
+		return func() {}, nil
+	}
+	logger.DebugOpf(op, "acquiring a conversion slot: %s", a)
+	var err error
+	if wait {
+		err = a.Acquire(ctx)
+	} else {
+		err = a.TryAcquire()
+	}
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	logger.DebugOp(op, "conversion slot acquired")
+	return a.Release, nil
+}
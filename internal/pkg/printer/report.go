@@ -0,0 +1,202 @@
+package printer
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xpdf"
+)
+
+// Warning is an issue found during
+// a conversion which does not stop it.
+type Warning struct {
+	// Code identifies the kind of
+	// issue (e.g. "missing-alt").
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Element describes the HTML
+	// element at fault, if any.
+	Element string `json:"element,omitempty"`
+}
+
+// ConsoleEntry is a JavaScript exception
+// or console message of a conversion.
+type ConsoleEntry struct {
+	// Type is "exception" or the type of
+	// the console message (e.g. "error").
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+/*
+Reporter is a Printer which may report
+warnings and console entries about its
+last conversion.
+*/
+type Reporter interface {
+	Warnings() []Warning
+	ConsoleEntries() []ConsoleEntry
+}
+
+// TruncatedWarningCode is the code of the warning
+// telling that the next warnings are ignored.
+const TruncatedWarningCode string = "truncated"
+
+const (
+	// maxWarnings is the maximum number
+	// of reported warnings.
+	maxWarnings int = 50
+	/*
+		maxWarningsSize is the maximum size in bytes
+		of the reported warnings as JSON, so that
+		they fit in a response header.
+	*/
+	maxWarningsSize int = 4096
+	// maxWarningFieldLength is the maximum length
+	// of the message and the element of a reported
+	// warning.
+	maxWarningFieldLength int = 200
+	// maxConsoleEntries is the maximum number
+	// of reported console entries.
+	maxConsoleEntries int = 50
+	// maxConsoleMessageLength is the maximum
+	// length of a reported console message.
+	maxConsoleMessageLength int = 500
+)
+
+/*
+report collects the Warning, the
+ConsoleEntry and the document metadata
+of a conversion. It is safe for
+concurrent use.
+
+A nil *report ignores everything.
+*/
+type report struct {
+	mu             sync.Mutex
+	warnings       []Warning
+	warningsSize   int
+	truncated      bool
+	consoleEntries []ConsoleEntry
+	metadata       xpdf.Metadata
+}
+
+/*
+addWarnings adds the given warnings, until
+there are too many of them or they are too
+large. The first ignored warning adds a
+warning with the TruncatedWarningCode.
+Their message and element are truncated
+if too long.
+*/
+func (r *report) addWarnings(warnings ...Warning) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, warning := range warnings {
+		if r.truncated {
+			return
+		}
+		warning.Message = truncate(warning.Message, maxWarningFieldLength)
+		warning.Element = truncate(warning.Element, maxWarningFieldLength)
+		b, err := json.Marshal(warning)
+		if err != nil {
+			continue
+		}
+		// each warning also takes a
+		// comma in the JSON array.
+		size := len(b) + 1
+		if len(r.warnings) >= maxWarnings || r.warningsSize+size > maxWarningsSize {
+			r.truncated = true
+			r.warnings = append(r.warnings, Warning{
+				Code:    TruncatedWarningCode,
+				Message: "too many warnings: the next ones have been ignored",
+			})
+			return
+		}
+		r.warningsSize += size
+		r.warnings = append(r.warnings, warning)
+	}
+}
+
+/*
+addConsoleEntry adds the given ConsoleEntry,
+unless there are already too many of them.
+Its message is truncated if too long.
+*/
+func (r *report) addConsoleEntry(entry ConsoleEntry) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.consoleEntries) >= maxConsoleEntries {
+		return
+	}
+	entry.Message = truncate(entry.Message, maxConsoleMessageLength)
+	r.consoleEntries = append(r.consoleEntries, entry)
+}
+
+func (r *report) reset() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.warnings = nil
+	r.warningsSize = 0
+	r.truncated = false
+	r.consoleEntries = nil
+	r.metadata = xpdf.Metadata{}
+}
+
+func (r *report) setMetadata(metadata xpdf.Metadata) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metadata = metadata
+}
+
+func (r *report) documentMetadata() xpdf.Metadata {
+	if r == nil {
+		return xpdf.Metadata{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.metadata
+}
+
+func (r *report) allWarnings() []Warning {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make([]Warning, len(r.warnings))
+	copy(result, r.warnings)
+	return result
+}
+
+func (r *report) allConsoleEntries() []ConsoleEntry {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make([]ConsoleEntry, len(r.consoleEntries))
+	copy(result, r.consoleEntries)
+	return result
+}
+
+// truncate shortens the given string to
+// the given number of runes, if longer.
+func truncate(s string, length int) string {
+	if runes := []rune(s); len(runes) > length {
+		return string(runes[:length]) + "..."
+	}
+	return s
+}
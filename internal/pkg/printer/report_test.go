@@ -0,0 +1,76 @@
+package printer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport(t *testing.T) {
+	r := &report{}
+	// should be OK.
+	r.addWarnings(Warning{Code: MissingAltWarningCode, Message: "foo"})
+	r.addConsoleEntry(ConsoleEntry{Type: "log", Message: "foo"})
+	assert.Len(t, r.allWarnings(), 1)
+	assert.Equal(t, []ConsoleEntry{{Type: "log", Message: "foo"}}, r.allConsoleEntries())
+	// should truncate the message.
+	r.addConsoleEntry(ConsoleEntry{Type: "log", Message: strings.Repeat("é", maxConsoleMessageLength+1)})
+	entries := r.allConsoleEntries()
+	assert.Equal(t, strings.Repeat("é", maxConsoleMessageLength)+"...", entries[1].Message)
+	// should ignore the entries
+	// over the maximum.
+	for i := 0; i < maxConsoleEntries; i++ {
+		r.addConsoleEntry(ConsoleEntry{Type: "log", Message: "bar"})
+	}
+	assert.Len(t, r.allConsoleEntries(), maxConsoleEntries)
+	// should be empty.
+	r.reset()
+	assert.Empty(t, r.allWarnings())
+	assert.Empty(t, r.allConsoleEntries())
+	// should truncate the message
+	// and the element.
+	r.addWarnings(Warning{
+		Code:    ResourceFailureWarningCode,
+		Message: strings.Repeat("é", maxWarningFieldLength+1),
+		Element: strings.Repeat("a", maxWarningFieldLength+1),
+	})
+	warnings := r.allWarnings()
+	assert.Equal(t, strings.Repeat("é", maxWarningFieldLength)+"...", warnings[0].Message)
+	assert.Equal(t, strings.Repeat("a", maxWarningFieldLength)+"...", warnings[0].Element)
+	r.reset()
+	// should ignore the warnings over
+	// the maximum and say so once.
+	for i := 0; i < maxWarnings+10; i++ {
+		r.addWarnings(Warning{Code: MissingAltWarningCode, Message: "foo"})
+	}
+	warnings = r.allWarnings()
+	assert.Len(t, warnings, maxWarnings+1)
+	assert.Equal(t, TruncatedWarningCode, warnings[maxWarnings].Code)
+	r.reset()
+	// should ignore the warnings over
+	// the maximum size and say so once.
+	for i := 0; i < maxWarnings; i++ {
+		r.addWarnings(Warning{
+			Code:    BlockedRequestWarningCode,
+			Message: strings.Repeat("a", maxWarningFieldLength),
+			Element: strings.Repeat("b", maxWarningFieldLength),
+		})
+	}
+	warnings = r.allWarnings()
+	assert.Less(t, len(warnings), maxWarnings)
+	assert.Equal(t, TruncatedWarningCode, warnings[len(warnings)-1].Code)
+	b, err := json.Marshal(warnings[:len(warnings)-1])
+	assert.Nil(t, err)
+	assert.LessOrEqual(t, len(b), maxWarningsSize)
+	r.reset()
+	assert.Empty(t, r.allWarnings())
+	// should be OK as a nil report
+	// ignores everything.
+	var nilReport *report
+	nilReport.addWarnings(Warning{Code: MissingAltWarningCode})
+	nilReport.addConsoleEntry(ConsoleEntry{Type: "log"})
+	assert.Nil(t, nilReport.allWarnings())
+	assert.Nil(t, nilReport.allConsoleEntries())
+}
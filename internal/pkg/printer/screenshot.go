@@ -0,0 +1,218 @@
+package printer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+
+	"github.com/mafredri/cdp"
+	"github.com/mafredri/cdp/protocol/page"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+)
+
+const (
+	// PNGScreenshotFormat is the
+	// PNG screenshot format.
+	PNGScreenshotFormat string = "png"
+	// JPEGScreenshotFormat is the
+	// JPEG screenshot format.
+	JPEGScreenshotFormat string = "jpeg"
+	// WebPScreenshotFormat is the
+	// WebP screenshot format.
+	WebPScreenshotFormat string = "webp"
+	// ClippedScreenshotWarningCode is the code of
+	// the warning for a page higher than the
+	// maximum screenshot height.
+	ClippedScreenshotWarningCode string = "clipped-screenshot"
+)
+
+/*
+ScreenshotFormats returns a slice
+containing all available screenshot
+formats.
+*/
+func ScreenshotFormats() []string {
+	return []string{
+		PNGScreenshotFormat,
+		JPEGScreenshotFormat,
+		WebPScreenshotFormat,
+	}
+}
+
+// ScreenshotOptions helps customizing the
+// Google Chrome screenshot behaviour.
+type ScreenshotOptions struct {
+	Format       string
+	Quality      int64
+	FullPage     bool
+	ClipSelector string
+	// MaxHeight is the maximum height, in pixels,
+	// of the captured area: a higher page would
+	// make Google Chrome allocate a huge bitmap.
+	MaxHeight int64
+}
+
+// DefaultScreenshotOptions returns the default
+// Google Chrome screenshot options.
+func DefaultScreenshotOptions() ScreenshotOptions {
+	return ScreenshotOptions{
+		Format:       PNGScreenshotFormat,
+		Quality:      100,
+		FullPage:     false,
+		ClipSelector: "",
+		MaxHeight:    16384,
+	}
+}
+
+// NewHTMLScreenshotPrinter returns a Printer which
+// is able to take a screenshot of an HTML file.
+func NewHTMLScreenshotPrinter(logger xlog.Logger, fpath string, opts ChromePrinterOptions, screenshotOpts ScreenshotOptions) Printer {
+	URL := fmt.Sprintf("file://%s", fpath)
+	return chromePrinter{
+		logger:         logger,
+		url:            URL,
+		opts:           opts,
+		screenshotOpts: &screenshotOpts,
+		report:         &report{},
+	}
+}
+
+// NewURLScreenshotPrinter returns a Printer which
+// is able to take a screenshot of a URL.
+func NewURLScreenshotPrinter(logger xlog.Logger, url string, opts ChromePrinterOptions, screenshotOpts ScreenshotOptions) Printer {
+	return chromePrinter{
+		logger:         logger,
+		url:            url,
+		opts:           opts,
+		screenshotOpts: &screenshotOpts,
+		report:         &report{},
+	}
+}
+
+func (p chromePrinter) captureScreenshot(ctx context.Context, client *cdp.Client, destination string) error {
+	const op string = "printer.chromePrinter.captureScreenshot"
+	resolver := func() error {
+		args := page.NewCaptureScreenshotArgs().
+			SetFormat(p.screenshotOpts.Format)
+		if p.screenshotOpts.Format != PNGScreenshotFormat {
+			args.SetQuality(int(p.screenshotOpts.Quality))
+		}
+		if p.screenshotOpts.FullPage || p.screenshotOpts.ClipSelector != "" {
+			/*
+				Google Chrome only renders what is inside
+				the viewport: we resize it so that it
+				contains the whole page.
+			*/
+			metrics, err := client.Page.GetLayoutMetrics(ctx)
+			if err != nil {
+				return err
+			}
+			height := int64(math.Ceil(metrics.ContentSize.Height))
+			if height > p.screenshotOpts.MaxHeight {
+				p.logger.DebugOpf(op, "clipping the page height %d to %d", height, p.screenshotOpts.MaxHeight)
+				height = p.screenshotOpts.MaxHeight
+			}
+			if height > p.opts.ViewportHeight {
+				if err := p.setViewport(ctx, client, p.opts.ViewportWidth, height); err != nil {
+					return err
+				}
+			}
+			clip := page.Viewport{
+				X:      0,
+				Y:      0,
+				Width:  metrics.ContentSize.Width,
+				Height: metrics.ContentSize.Height,
+				Scale:  1,
+			}
+			if p.screenshotOpts.ClipSelector != "" {
+				clip, err = p.clipSelector(ctx, client)
+				if err != nil {
+					return err
+				}
+			}
+			if maxHeight := float64(p.screenshotOpts.MaxHeight); clip.Y+clip.Height > maxHeight {
+				if clip.Y >= maxHeight {
+					return xerror.Invalid(
+						op,
+						fmt.Sprintf("the area to capture starts below the maximum screenshot height of %dpx", p.screenshotOpts.MaxHeight),
+						nil,
+					)
+				}
+				clip.Height = maxHeight - clip.Y
+				p.report.addWarnings(Warning{
+					Code:    ClippedScreenshotWarningCode,
+					Message: fmt.Sprintf("the screenshot has been clipped to the maximum height of %dpx", p.screenshotOpts.MaxHeight),
+				})
+			}
+			args.SetClip(clip)
+		}
+		p.logger.DebugOp(op, "starting CaptureScreenshot")
+		screenshot, err := client.Page.CaptureScreenshot(ctx, args)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(destination, screenshot.Data, 0600)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+func (p chromePrinter) clipSelector(ctx context.Context, client *cdp.Client) (page.Viewport, error) {
+	const op string = "printer.chromePrinter.clipSelector"
+	resolver := func() (page.Viewport, error) {
+		selector, err := json.Marshal(p.screenshotOpts.ClipSelector)
+		if err != nil {
+			return page.Viewport{}, err
+		}
+		// coordinates are relative to the document,
+		// not to the viewport.
+		expr := fmt.Sprintf(`(() => {
+			const el = document.querySelector(%s);
+			if (!el) {
+				return { found: false };
+			}
+			const rect = el.getBoundingClientRect();
+			return {
+				found: true,
+				x: rect.left + window.scrollX,
+				y: rect.top + window.scrollY,
+				width: rect.width,
+				height: rect.height
+			};
+		})()`, selector)
+		var result struct {
+			Found  bool    `json:"found"`
+			X      float64 `json:"x"`
+			Y      float64 `json:"y"`
+			Width  float64 `json:"width"`
+			Height float64 `json:"height"`
+		}
+		if err := Eval(ctx, client, expr, &result); err != nil {
+			return page.Viewport{}, err
+		}
+		if !result.Found || result.Width == 0 || result.Height == 0 {
+			return page.Viewport{}, xerror.Invalid(
+				op,
+				fmt.Sprintf("no visible element matches the selector '%s'", p.screenshotOpts.ClipSelector),
+				nil,
+			)
+		}
+		return page.Viewport{
+			X:      result.X,
+			Y:      result.Y,
+			Width:  result.Width,
+			Height: result.Height,
+			Scale:  1,
+		}, nil
+	}
+	clip, err := resolver()
+	if err != nil {
+		return clip, xerror.New(op, err)
+	}
+	return clip, nil
+}
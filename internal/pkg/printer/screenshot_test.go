@@ -0,0 +1,66 @@
+package printer
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestScreenshotPrinter(t *testing.T) {
+	var (
+		logger         xlog.Logger          = test.DebugLogger()
+		config         conf.Config          = conf.DefaultConfig()
+		fpath          string               = test.HTMLFpaths(t)[0]
+		URL                                 = "https://google.com"
+		opts           ChromePrinterOptions = DefaultChromePrinterOptions(config)
+		screenshotOpts ScreenshotOptions
+		dest           string
+		p              Printer
+		err            error
+	)
+	// default options.
+	screenshotOpts = DefaultScreenshotOptions()
+	p = NewHTMLScreenshotPrinter(logger, fpath, opts, screenshotOpts)
+	dest = test.GenerateDestination()
+	err = p.Print(context.Background(), dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// options with a JPEG full page.
+	screenshotOpts = DefaultScreenshotOptions()
+	screenshotOpts.Format = JPEGScreenshotFormat
+	screenshotOpts.Quality = 80
+	screenshotOpts.FullPage = true
+	p = NewURLScreenshotPrinter(logger, URL, opts, screenshotOpts)
+	dest = test.GenerateDestination()
+	err = p.Print(context.Background(), dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// options with a clip selector.
+	screenshotOpts = DefaultScreenshotOptions()
+	screenshotOpts.ClipSelector = "body"
+	p = NewHTMLScreenshotPrinter(logger, fpath, opts, screenshotOpts)
+	dest = test.GenerateDestination()
+	err = p.Print(context.Background(), dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as no element
+	// matches the clip selector.
+	screenshotOpts = DefaultScreenshotOptions()
+	screenshotOpts.ClipSelector = "#foo"
+	p = NewHTMLScreenshotPrinter(logger, fpath, opts, screenshotOpts)
+	dest = test.GenerateDestination()
+	err = p.Print(context.Background(), dest)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,174 @@
+package printer
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/thecodingmachine/gotenberg/internal/pkg/admission"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xcontext"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xpdf"
+)
+
+const (
+	// RangesSplitMode is the split mode
+	// which extracts the given page ranges.
+	RangesSplitMode string = "ranges"
+	// PagesSplitMode is the split mode
+	// which extracts one file per page.
+	PagesSplitMode string = "pages"
+)
+
+/*
+SplitModes returns a slice
+containing all available split
+modes.
+*/
+func SplitModes() []string {
+	return []string{
+		RangesSplitMode,
+		PagesSplitMode,
+	}
+}
+
+type splitPrinter struct {
+	logger xlog.Logger
+	fpath  string
+	opts   SplitPrinterOptions
+}
+
+// SplitPrinterOptions helps customizing the
+// split Printer behaviour.
+type SplitPrinterOptions struct {
+	WaitTimeout float64
+	Mode        string
+	PageRanges  []xpdf.PageRange
+	Admission   *admission.Controller
+}
+
+// DefaultSplitPrinterOptions returns the default
+// split Printer options.
+func DefaultSplitPrinterOptions(config conf.Config) SplitPrinterOptions {
+	return SplitPrinterOptions{
+		WaitTimeout: config.DefaultWaitTimeout(),
+		Mode:        RangesSplitMode,
+		PageRanges:  nil,
+		Admission:   nil,
+	}
+}
+
+/*
+SplitExt returns the extension of the
+result file of a split Printer: ".pdf"
+if there is only one page range, ".zip"
+otherwise.
+*/
+func SplitExt(opts SplitPrinterOptions) string {
+	if opts.Mode == RangesSplitMode && len(opts.PageRanges) == 1 {
+		return ".pdf"
+	}
+	return ".zip"
+}
+
+// NewSplitPrinter returns a Printer which
+// is able to split a PDF.
+func NewSplitPrinter(logger xlog.Logger, fpath string, opts SplitPrinterOptions) Printer {
+	return splitPrinter{
+		logger: logger,
+		fpath:  fpath,
+		opts:   opts,
+	}
+}
+
+func (p splitPrinter) Print(parent context.Context, destination string) error {
+	const op string = "printer.splitPrinter.Print"
+	logOptions(p.logger, p.opts)
+	// the time spent in the queue does not
+	// count in the conversion timeout.
+	release, err := admit(parent, p.logger, p.opts.Admission, true)
+	if err != nil {
+		return xcontext.MustHandleError(
+			parent,
+			xerror.New(op, err),
+		)
+	}
+	defer release()
+	ctx, cancel := xcontext.WithTimeout(parent, p.logger, p.opts.WaitTimeout)
+	defer cancel()
+	resolver := func() error {
+		dirPath, err := ioutil.TempDir(filepath.Dir(destination), "split")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dirPath) // nolint: errcheck
+		var ranges []xpdf.PageRange
+		if p.opts.Mode == RangesSplitMode {
+			ranges = p.opts.PageRanges
+		}
+		fpaths, err := xpdf.Split(ctx, p.logger, p.fpath, ranges, dirPath)
+		if err != nil {
+			return err
+		}
+		if SplitExt(p.opts) == ".pdf" {
+			return os.Rename(fpaths[0], destination)
+		}
+		p.logger.DebugOpf(op, "archiving '%v'...", fpaths)
+		return archive(fpaths, destination)
+	}
+	if err := resolver(); err != nil {
+		return xcontext.MustHandleError(
+			ctx,
+			xerror.New(op, err),
+		)
+	}
+	return nil
+}
+
+// archive writes the given files
+// into a ZIP archive.
+func archive(fpaths []string, destination string) error {
+	const op string = "printer.archive"
+	resolver := func() error {
+		dest, err := os.Create(destination)
+		if err != nil {
+			return err
+		}
+		defer dest.Close() // nolint: errcheck
+		w := zip.NewWriter(dest)
+		for _, fpath := range fpaths {
+			f, err := os.Open(fpath)
+			if err != nil {
+				return err
+			}
+			entry, err := w.Create(filepath.Base(fpath))
+			if err != nil {
+				f.Close() // nolint: errcheck
+				return err
+			}
+			_, err = io.Copy(entry, f)
+			f.Close() // nolint: errcheck
+			if err != nil {
+				return err
+			}
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		return dest.Close()
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = Printer(new(splitPrinter))
+)
@@ -0,0 +1,66 @@
+package printer
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xpdf"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xrand"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestSplitPrinter(t *testing.T) {
+	var (
+		logger xlog.Logger = test.DebugLogger()
+		config conf.Config = conf.DefaultConfig()
+		fpath  string      = test.MergeFpaths(t)[0]
+		opts   SplitPrinterOptions
+		dest   string
+		p      Printer
+		err    error
+	)
+	// one page range.
+	opts = DefaultSplitPrinterOptions(config)
+	opts.PageRanges = []xpdf.PageRange{{From: 1, To: 1}}
+	assert.Equal(t, ".pdf", SplitExt(opts))
+	p = NewSplitPrinter(logger, fpath, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(context.Background(), dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// one file per page.
+	opts = DefaultSplitPrinterOptions(config)
+	opts.Mode = PagesSplitMode
+	assert.Equal(t, ".zip", SplitExt(opts))
+	p = NewSplitPrinter(logger, fpath, opts)
+	dest = fmt.Sprintf("/tmp/%s.zip", xrand.Get())
+	err = p.Print(context.Background(), dest)
+	assert.Nil(t, err)
+	archive, err := zip.OpenReader(dest)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, archive.File)
+	err = archive.Close()
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as context.Context
+	// should timeout.
+	opts = DefaultSplitPrinterOptions(config)
+	opts.Mode = PagesSplitMode
+	opts.WaitTimeout = 0.0
+	p = NewSplitPrinter(logger, fpath, opts)
+	dest = fmt.Sprintf("/tmp/%s.zip", xrand.Get())
+	err = p.Print(context.Background(), dest)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.TimeoutCode, xerror.Code(err))
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+}
@@ -11,5 +11,6 @@ func NewURLPrinter(logger xlog.Logger, url string, opts ChromePrinterOptions) Pr
 		logger: logger,
 		url:    url,
 		opts:   opts,
+		report: &report{},
 	}
 }
@@ -1,6 +1,7 @@
 package printer
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -25,7 +26,7 @@ func TestURLPrinter(t *testing.T) {
 	opts = DefaultChromePrinterOptions(config)
 	p = NewURLPrinter(logger, URL, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
@@ -34,7 +35,7 @@ func TestURLPrinter(t *testing.T) {
 	opts.WaitDelay = 0.5
 	p = NewURLPrinter(logger, URL, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
@@ -43,7 +44,7 @@ func TestURLPrinter(t *testing.T) {
 	opts.PageRanges = "1"
 	p = NewURLPrinter(logger, URL, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
@@ -53,7 +54,7 @@ func TestURLPrinter(t *testing.T) {
 	opts.PageRanges = "foo"
 	p = NewURLPrinter(logger, URL, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	test.AssertError(t, err)
 	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
 	err = os.RemoveAll(dest)
@@ -64,7 +65,7 @@ func TestURLPrinter(t *testing.T) {
 	opts.WaitTimeout = 0.0
 	p = NewURLPrinter(logger, URL, opts)
 	dest = test.GenerateDestination()
-	err = p.Print(dest)
+	err = p.Print(context.Background(), dest)
 	test.AssertError(t, err)
 	assert.Equal(t, xerror.TimeoutCode, xerror.Code(err))
 	err = os.RemoveAll(dest)
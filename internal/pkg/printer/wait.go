@@ -0,0 +1,114 @@
+package printer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mafredri/cdp"
+	"github.com/mafredri/cdp/protocol/runtime"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+)
+
+/*
+selectorExpression returns the JavaScript expression
+which is true once an element matches the given CSS
+selector and, if asked, once this element is visible.
+*/
+func selectorExpression(selector string, visible bool) (string, error) {
+	quoted, err := json.Marshal(selector)
+	if err != nil {
+		return "", err
+	}
+	if !visible {
+		return fmt.Sprintf("document.querySelector(%s) !== null", quoted), nil
+	}
+	return fmt.Sprintf(`(() => {
+	const el = document.querySelector(%s);
+	if (el === null) {
+		return false;
+	}
+	const style = window.getComputedStyle(el);
+	const rect = el.getBoundingClientRect();
+	return style.display !== 'none' && style.visibility !== 'hidden' && rect.width > 0 && rect.height > 0;
+})()`, quoted), nil
+}
+
+/*
+waitForSelector waits until an element matches
+the CSS selector of the options and, if asked,
+until this element is visible.
+
+It returns an xerror.Error with xerror.InvalidCode
+if the selector is not valid.
+*/
+func (p chromePrinter) waitForSelector(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.waitForSelector"
+	resolver := func() error {
+		expr, err := selectorExpression(p.opts.WaitForSelector, p.opts.WaitForSelectorVisible)
+		if err != nil {
+			return err
+		}
+		p.logger.DebugOpf(op, "waiting for selector '%s' (visible: %t)...", p.opts.WaitForSelector, p.opts.WaitForSelectorVisible)
+		if err := Wait(ctx, client, expr); err != nil {
+			var exception *runtime.ExceptionDetails
+			if errors.As(err, &exception) {
+				return xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' is not a valid CSS selector", p.opts.WaitForSelector),
+					err,
+				)
+			}
+			return err
+		}
+		return nil
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+waitForExpression waits until the JavaScript
+expression of the options, or the value of its
+promise, is truthy.
+
+It returns an xerror.Error with xerror.InvalidCode
+if the expression throws an exception.
+*/
+func (p chromePrinter) waitForExpression(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.waitForExpression"
+	resolver := func() error {
+		expr := fmt.Sprintf("(async () => !!(await (%s)))()", p.opts.WaitForExpression)
+		p.logger.DebugOpf(op, "waiting for expression '%s'...", p.opts.WaitForExpression)
+		if err := WaitPromise(ctx, client, expr); err != nil {
+			var exception *runtime.ExceptionDetails
+			if errors.As(err, &exception) {
+				return xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' is not a valid JavaScript expression: %s", p.opts.WaitForExpression, exception.Error()),
+					err,
+				)
+			}
+			return err
+		}
+		return nil
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+// waitForFonts waits until the
+// web fonts of the page are loaded.
+func (p chromePrinter) waitForFonts(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.waitForFonts"
+	p.logger.DebugOp(op, "waiting for web fonts...")
+	if err := EvalPromise(ctx, client, "document.fonts.ready.then(() => true)", nil); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectorExpression(t *testing.T) {
+	// should be OK.
+	expr, err := selectorExpression(`div[data-ready="true"]`, false)
+	require.Nil(t, err)
+	assert.Equal(t, `document.querySelector("div[data-ready=\"true\"]") !== null`, expr)
+	// should also check the visibility.
+	expr, err = selectorExpression("#chart", true)
+	require.Nil(t, err)
+	assert.Contains(t, expr, `document.querySelector("#chart")`)
+	assert.Contains(t, expr, "getComputedStyle")
+}
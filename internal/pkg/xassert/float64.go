@@ -52,6 +52,35 @@ func Float64NotInferiorTo(lowerBound float64) RuleFloat64 {
 	}
 }
 
+type ruleFloat64SuperiorTo struct {
+	*baseRuleFloat64
+	lowerBound float64
+}
+
+func (r ruleFloat64SuperiorTo) validate() error {
+	const op string = "xassert.ruleFloat64SuperiorTo.validate"
+	if r.value <= r.lowerBound {
+		return xerror.Invalid(
+			op,
+			fmt.Sprintf("'%s' should be > '%f', got '%f'", r.key, r.lowerBound, r.value),
+			nil,
+		)
+	}
+	return nil
+}
+
+/*
+Float64SuperiorTo returns a RuleFloat64 for
+validating that a float64 is strictly superior
+to given lower bound.
+*/
+func Float64SuperiorTo(lowerBound float64) RuleFloat64 {
+	return ruleFloat64SuperiorTo{
+		&baseRuleFloat64{},
+		lowerBound,
+	}
+}
+
 type ruleFloat64NotSuperiorTo struct {
 	*baseRuleFloat64
 	upperBound float64
@@ -84,5 +113,6 @@ func Float64NotSuperiorTo(upperBound float64) RuleFloat64 {
 // Compile-time checks to ensure type implements desired interfaces.
 var (
 	_ = RuleFloat64(new(ruleFloat64NotInferiorTo))
+	_ = RuleFloat64(new(ruleFloat64SuperiorTo))
 	_ = RuleFloat64(new(ruleFloat64NotSuperiorTo))
 )
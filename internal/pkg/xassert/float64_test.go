@@ -19,6 +19,21 @@ func TestFloat64NotInferiorTo(t *testing.T) {
 	test.AssertError(t, err)
 }
 
+func TestFloat64SuperiorTo(t *testing.T) {
+	rule := Float64SuperiorTo(0.0)
+	// should be OK.
+	rule.with("FOO", 10.0)
+	err := rule.validate()
+	assert.Nil(t, err)
+	// should not be OK.
+	rule.with("FOO", 0.0)
+	err = rule.validate()
+	test.AssertError(t, err)
+	rule.with("FOO", -10.0)
+	err = rule.validate()
+	test.AssertError(t, err)
+}
+
 func TestFloat64NotSuperiorTo(t *testing.T) {
 	rule := Float64NotSuperiorTo(0.0)
 	// should be OK.
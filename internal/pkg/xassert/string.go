@@ -2,9 +2,17 @@ package xassert
 
 import (
 	"fmt"
+	"net"
 	"strings"
+	"time"
+
+	// embed the IANA time zone database, so that
+	// the validation does not depend on the
+	// zoneinfo files of the host.
+	_ "time/tzdata"
 
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"golang.org/x/text/language"
 )
 
 // RuleString is an interface for
@@ -113,9 +121,97 @@ func StringEndWith(endWith string) RuleString {
 	}
 }
 
+type ruleStringTimezone struct {
+	*baseRuleString
+}
+
+func (r ruleStringTimezone) validate() error {
+	const op string = "xassert.ruleStringTimezone.validate"
+	// time.LoadLocation also accepts "" and
+	// "Local", which are not IANA zone names.
+	if r.value != "" && r.value != "Local" {
+		if _, err := time.LoadLocation(r.value); err == nil {
+			return nil
+		}
+	}
+	return xerror.Invalid(
+		op,
+		fmt.Sprintf("'%s' should be an IANA time zone name (e.g. 'Europe/Paris'), got '%s'", r.key, r.value),
+		nil,
+	)
+}
+
+/*
+StringTimezone returns a RuleString for
+validating that a string is an IANA
+time zone name.
+*/
+func StringTimezone() RuleString {
+	return ruleStringTimezone{
+		&baseRuleString{},
+	}
+}
+
+type ruleStringLanguageTag struct {
+	*baseRuleString
+}
+
+func (r ruleStringLanguageTag) validate() error {
+	const op string = "xassert.ruleStringLanguageTag.validate"
+	if _, err := language.Parse(r.value); err == nil {
+		return nil
+	}
+	return xerror.Invalid(
+		op,
+		fmt.Sprintf("'%s' should be a BCP 47 language tag (e.g. 'fr-FR'), got '%s'", r.key, r.value),
+		nil,
+	)
+}
+
+/*
+StringLanguageTag returns a RuleString for
+validating that a string is a BCP 47
+language tag.
+*/
+func StringLanguageTag() RuleString {
+	return ruleStringLanguageTag{
+		&baseRuleString{},
+	}
+}
+
+type ruleStringCIDR struct {
+	*baseRuleString
+}
+
+func (r ruleStringCIDR) validate() error {
+	const op string = "xassert.ruleStringCIDR.validate"
+	if _, _, err := net.ParseCIDR(r.value); err == nil {
+		return nil
+	}
+	return xerror.Invalid(
+		op,
+		fmt.Sprintf("'%s' should be a CIDR notation (e.g. '10.0.0.0/8'), got '%s'", r.key, r.value),
+		nil,
+	)
+}
+
+/*
+StringCIDR returns a RuleString for
+validating that a string is an IP
+network in CIDR notation.
+*/
+func StringCIDR() RuleString {
+	return ruleStringCIDR{
+		&baseRuleString{},
+	}
+}
+
 // Compile-time checks to ensure type implements desired interfaces.
 var (
 	_ = RuleString(new(ruleStringOneOf))
 	_ = RuleString(new(ruleStringStartWith))
 	_ = RuleString(new(ruleStringEndWith))
+	_ = RuleString(new(ruleStringTimezone))
+	_ = RuleString(new(ruleStringLanguageTag))
+	_ = RuleString(new(ruleStringCIDR))
 )
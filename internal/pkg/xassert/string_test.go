@@ -42,3 +42,60 @@ func TestStringEndWith(t *testing.T) {
 	err = rule.validate()
 	test.AssertError(t, err)
 }
+
+func TestStringTimezone(t *testing.T) {
+	rule := StringTimezone()
+	// should be OK.
+	rule.with("FOO", "Europe/Paris")
+	err := rule.validate()
+	assert.Nil(t, err)
+	rule.with("FOO", "UTC")
+	err = rule.validate()
+	assert.Nil(t, err)
+	// should not be OK.
+	rule.with("FOO", "Europe/Foo")
+	err = rule.validate()
+	test.AssertError(t, err)
+	rule.with("FOO", "Local")
+	err = rule.validate()
+	test.AssertError(t, err)
+	rule.with("FOO", "../../etc/passwd")
+	err = rule.validate()
+	test.AssertError(t, err)
+}
+
+func TestStringLanguageTag(t *testing.T) {
+	rule := StringLanguageTag()
+	// should be OK.
+	rule.with("FOO", "fr-FR")
+	err := rule.validate()
+	assert.Nil(t, err)
+	rule.with("FOO", "zh-Hant-TW")
+	err = rule.validate()
+	assert.Nil(t, err)
+	// should not be OK.
+	rule.with("FOO", "french")
+	err = rule.validate()
+	test.AssertError(t, err)
+	rule.with("FOO", "fr_FR;q=0.9")
+	err = rule.validate()
+	test.AssertError(t, err)
+}
+
+func TestStringCIDR(t *testing.T) {
+	rule := StringCIDR()
+	// should be OK.
+	rule.with("FOO", "10.0.0.0/8")
+	err := rule.validate()
+	assert.Nil(t, err)
+	rule.with("FOO", "fd00::/8")
+	err = rule.validate()
+	assert.Nil(t, err)
+	// should not be OK.
+	rule.with("FOO", "10.0.0.1")
+	err = rule.validate()
+	test.AssertError(t, err)
+	rule.with("FOO", "10.0.0.0/33")
+	err = rule.validate()
+	test.AssertError(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/dustin/go-humanize"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
@@ -47,6 +48,52 @@ func StringFromEnv(envVar, defaultValue string, rules ...RuleString) (string, er
 	return result, nil
 }
 
+/*
+Strings splits a comma-separated string and
+applies validation on each of its values.
+
+If string is empty or validation fails,
+returns the default value.
+
+The key is used to identify the value.
+*/
+func Strings(key, value string, defaultValue []string, rules ...RuleString) ([]string, error) {
+	const op string = "xassert.Strings"
+	if value == "" {
+		return defaultValue, nil
+	}
+	var result []string
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		for _, rule := range rules {
+			rule.with(key, v)
+			if err := rule.validate(); err != nil {
+				return defaultValue, xerror.New(op, err)
+			}
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+/*
+StringsFromEnv returns the comma-separated values
+of given environment variable or the default value
+if not found or validation fails.
+*/
+func StringsFromEnv(envVar string, defaultValue []string, rules ...RuleString) ([]string, error) {
+	const op string = "xassert.StringsFromEnv"
+	value := os.Getenv(envVar)
+	result, err := Strings(envVar, value, defaultValue, rules...)
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
 /*
 Int64 tries to convert a string to an int64.
 
@@ -226,7 +273,7 @@ default value.
 func BytesFromEnv(envVar string, defaultValue int64, rules ...RuleInt64) (int64, error) {
 	const op string = "xassert.BytesFromEnv"
 	value := os.Getenv(envVar)
-	result, err := Bytes(envVar, value, defaultValue)
+	result, err := Bytes(envVar, value, defaultValue, rules...)
 	if err != nil {
 		return result, xerror.New(op, err)
 	}
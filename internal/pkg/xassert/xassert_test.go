@@ -65,6 +65,49 @@ func TestStringFromEnv(t *testing.T) {
 	os.Unsetenv(envVar)
 }
 
+func TestStrings(t *testing.T) {
+	defaultValue := []string{"FOO"}
+	rule := StringOneOf([]string{"FOO", "BAR"})
+	// empty value, result should be equal
+	// to the default value.
+	v, err := Strings("foo", "", defaultValue)
+	assert.Equal(t, defaultValue, v)
+	assert.Nil(t, err)
+	// result should be equal to given values
+	// as they are one of "FOO" and "BAR".
+	v, err = Strings("foo", "FOO, BAR,", defaultValue, rule)
+	assert.Equal(t, []string{"FOO", "BAR"}, v)
+	assert.Nil(t, err)
+	// should not be OK as a given value is
+	// not one of "FOO" and "BAR".
+	v, err = Strings("foo", "FOO,BAZ", defaultValue, rule)
+	assert.Equal(t, defaultValue, v)
+	test.AssertError(t, err)
+}
+
+func TestStringsFromEnv(t *testing.T) {
+	const envVar string = "FOO"
+	// no environment variable set,
+	// value should be equal to default value.
+	v, err := StringsFromEnv(envVar, nil)
+	assert.Nil(t, v)
+	assert.Nil(t, err)
+	// result should be equal to environment
+	// variable values.
+	os.Setenv(envVar, "FOO,BAR")
+	v, err = StringsFromEnv(envVar, nil)
+	assert.Equal(t, []string{"FOO", "BAR"}, v)
+	assert.Nil(t, err)
+	os.Unsetenv(envVar)
+	// should not be OK as environment variable
+	// value is not one of "FOO" and "BAR".
+	os.Setenv(envVar, "BAZ")
+	v, err = StringsFromEnv(envVar, nil, StringOneOf([]string{"FOO", "BAR"}))
+	assert.Nil(t, v)
+	test.AssertError(t, err)
+	os.Unsetenv(envVar)
+}
+
 func TestInt64(t *testing.T) {
 	const (
 		defaultValue int64 = 10
@@ -10,12 +10,17 @@ import (
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xtime"
 )
 
-// WithTimeout creates a context.Context which
-// times out after given seconds.
-func WithTimeout(logger xlog.Logger, seconds float64) (context.Context, context.CancelFunc) {
+/*
+WithTimeout creates a context.Context which
+times out after given seconds.
+
+It is also done as soon as the parent
+context.Context is done.
+*/
+func WithTimeout(parent context.Context, logger xlog.Logger, seconds float64) (context.Context, context.CancelFunc) {
 	const op string = "xcontext.WithTimeout"
 	logger.DebugOpf(op, "creating context with '%.2fs' of timeout...", seconds)
-	return context.WithTimeout(context.Background(), xtime.Duration(seconds))
+	return context.WithTimeout(parent, xtime.Duration(seconds))
 }
 
 /*
@@ -1,6 +1,7 @@
 package xcontext
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -15,18 +16,18 @@ func TestMustHandleError(t *testing.T) {
 	previousErr := errors.New("previous error")
 	logger := test.DebugLogger()
 	// context should not have an error.
-	ctx, cancel := WithTimeout(logger, 5)
+	ctx, cancel := WithTimeout(context.Background(), logger, 5)
 	defer cancel()
 	err := MustHandleError(ctx, previousErr)
 	assert.Equal(t, previousErr, err)
 	// should panic.
-	ctx, cancel = WithTimeout(logger, 5)
+	ctx, cancel = WithTimeout(context.Background(), logger, 5)
 	defer cancel()
 	assert.Panics(t, func() {
 		MustHandleError(ctx, nil)
 	})
 	// context should timed out.
-	ctx, cancel = WithTimeout(logger, 0.5)
+	ctx, cancel = WithTimeout(context.Background(), logger, 0.5)
 	defer cancel()
 	time.Sleep(xtime.Duration(1))
 	err = MustHandleError(ctx, previousErr)
@@ -34,9 +35,18 @@ func TestMustHandleError(t *testing.T) {
 	assert.Equal(t, xerror.TimeoutCode, xerror.Code(xerr))
 	// context should have an error different
 	// than context.DeadlineExceeded.
-	ctx, cancel = WithTimeout(logger, 5)
+	ctx, cancel = WithTimeout(context.Background(), logger, 5)
 	cancel()
 	err = MustHandleError(ctx, previousErr)
 	xerr = test.AssertError(t, err)
 	assert.Equal(t, xerror.InternalCode, xerror.Code(xerr))
+	// context should have an error as
+	// its parent has been canceled.
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel = WithTimeout(parent, logger, 5)
+	defer cancel()
+	cancelParent()
+	err = MustHandleError(ctx, previousErr)
+	xerr = test.AssertError(t, err)
+	assert.Equal(t, xerror.InternalCode, xerror.Code(xerr))
 }
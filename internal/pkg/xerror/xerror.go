@@ -18,6 +18,18 @@ const (
 	// TimeoutCode occurs when something
 	// timed out.
 	TimeoutCode ErrorCode = "timeout"
+	// NotFoundCode occurs when something
+	// does not exist.
+	NotFoundCode ErrorCode = "notfound"
+	// BusyCode occurs when there are
+	// too many ongoing operations.
+	BusyCode ErrorCode = "busy"
+	// UnavailableCode occurs when an operation
+	// cannot be handled in time.
+	UnavailableCode ErrorCode = "unavailable"
+	// ForbiddenCode occurs when an operation
+	// is not allowed.
+	ForbiddenCode ErrorCode = "forbidden"
 )
 
 // Error defines our standard application
@@ -87,6 +99,66 @@ func Timeout(op, message string, previous error) error {
 	}
 }
 
+/*
+NotFound returns a xerror.Error.
+
+Should be used when a requested
+entity does not exist.
+*/
+func NotFound(op, message string, previous error) error {
+	return &Error{
+		code:    NotFoundCode,
+		message: message,
+		op:      op,
+		err:     previous,
+	}
+}
+
+/*
+Busy returns a xerror.Error.
+
+Should be used when there are too many
+ongoing operations to accept a new one.
+*/
+func Busy(op, message string, previous error) error {
+	return &Error{
+		code:    BusyCode,
+		message: message,
+		op:      op,
+		err:     previous,
+	}
+}
+
+/*
+Unavailable returns a xerror.Error.
+
+Should be used when an operation
+could not be started in time.
+*/
+func Unavailable(op, message string, previous error) error {
+	return &Error{
+		code:    UnavailableCode,
+		message: message,
+		op:      op,
+		err:     previous,
+	}
+}
+
+/*
+Forbidden returns a xerror.Error.
+
+Should be used when an operation
+is not allowed.
+*/
+func Forbidden(op, message string, previous error) error {
+	return &Error{
+		code:    ForbiddenCode,
+		message: message,
+		op:      op,
+		err:     previous,
+	}
+}
+
 // Code returns the code of the root error, if available.
 // Otherwise returns InternalCode.
 func Code(err error) ErrorCode {
@@ -37,6 +37,33 @@ func scenario3() error {
 	return New("foo", nil)
 }
 
+/*
+Error 4.0: op = "foo"
+Error 4.1: code = "notfound", op = "bar", message = "nested error"
+*/
+func scenario4() error {
+	nestedErr := NotFound("bar", "nested error", nil)
+	return New("foo", nestedErr)
+}
+
+/*
+Error 5.0: op = "foo"
+Error 5.1: code = "busy", op = "bar", message = "nested error"
+*/
+func scenario5() error {
+	nestedErr := Busy("bar", "nested error", nil)
+	return New("foo", nestedErr)
+}
+
+/*
+Error 6.0: op = "foo"
+Error 6.1: code = "unavailable", op = "bar", message = "nested error"
+*/
+func scenario6() error {
+	nestedErr := Unavailable("bar", "nested error", nil)
+	return New("foo", nestedErr)
+}
+
 func TestError(t *testing.T) {
 	// should return the Error 1.3
 	// message.
@@ -48,6 +75,15 @@ func TestError(t *testing.T) {
 	assert.Equal(t, "<timeout> nested error", err.Error())
 }
 
+/*
+Error 7.0: op = "foo"
+Error 7.1: code = "forbidden", op = "bar", message = "nested error"
+*/
+func scenario7() error {
+	nestedErr := Forbidden("bar", "nested error", nil)
+	return New("foo", nestedErr)
+}
+
 func TestCode(t *testing.T) {
 	// should be an empty code if no error.
 	assert.Equal(t, "", fmt.Sprintf("%s", Code(nil)))
@@ -57,6 +93,18 @@ func TestCode(t *testing.T) {
 	// should be the code of Error 2.2.
 	err = scenario2()
 	assert.Equal(t, TimeoutCode, Code(err))
+	// should be the code of Error 4.1.
+	err = scenario4()
+	assert.Equal(t, NotFoundCode, Code(err))
+	// should be the code of Error 5.1.
+	err = scenario5()
+	assert.Equal(t, BusyCode, Code(err))
+	// should be the code of Error 6.1.
+	err = scenario6()
+	assert.Equal(t, UnavailableCode, Code(err))
+	// should be the code of Error 7.1.
+	err = scenario7()
+	assert.Equal(t, ForbiddenCode, Code(err))
 	// should be the default code.
 	err = scenario3()
 	assert.Equal(t, InternalCode, Code(err))
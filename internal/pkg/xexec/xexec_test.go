@@ -46,7 +46,7 @@ func TestRun(t *testing.T) {
 	assert.Nil(t, err)
 	// should not be OK as context.Context
 	// should timeout.
-	ctx, cancel := xcontext.WithTimeout(logger, 0)
+	ctx, cancel := xcontext.WithTimeout(context.Background(), logger, 0)
 	defer cancel()
 	err = Run(ctx, logger, "echo", "Hello", "World")
 	assert.NotNil(t, err)
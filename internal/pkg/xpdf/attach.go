@@ -0,0 +1,326 @@
+package xpdf
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+)
+
+const (
+	// FacturXFilename is the filename of
+	// the XML invoice of a Factur-X PDF file.
+	FacturXFilename string = "factur-x.xml"
+	// SourceRelationship is the AFRelationship
+	// of the source of the PDF file.
+	SourceRelationship string = "Source"
+	// DataRelationship is the AFRelationship
+	// of the data used for creating the PDF file.
+	DataRelationship string = "Data"
+	// AlternativeRelationship is the AFRelationship
+	// of an alternative representation of the PDF file.
+	AlternativeRelationship string = "Alternative"
+	// SupplementRelationship is the AFRelationship
+	// of a supplemental representation of the PDF file.
+	SupplementRelationship string = "Supplement"
+	// UnspecifiedRelationship is the AFRelationship
+	// of a file without known relationship.
+	UnspecifiedRelationship string = "Unspecified"
+)
+
+/*
+Relationships returns a slice
+containing all available
+AFRelationship values.
+*/
+func Relationships() []string {
+	return []string{
+		SourceRelationship,
+		DataRelationship,
+		AlternativeRelationship,
+		SupplementRelationship,
+		UnspecifiedRelationship,
+	}
+}
+
+// Attachment is a file embedded into
+// a PDF file as an associated file.
+type Attachment struct {
+	Fpath string
+	// Relationship is the AFRelationship of the
+	// file. It is ignored for a Factur-X invoice.
+	Relationship string
+}
+
+/*
+Attach embeds the given files into the given
+PDF file, in place, as associated files.
+
+A "factur-x.xml" file turns the PDF file into
+a Factur-X invoice: the PDF file should be a
+PDF/A-3 file.
+
+It returns an xerror.Error with xerror.InvalidCode
+if the Factur-X invoice is not valid.
+*/
+func Attach(ctx context.Context, logger xlog.Logger, fpath string, attachments []Attachment) error {
+	const op string = "xpdf.Attach"
+	resolver := func() error {
+		logger.DebugOpf(op, "reading '%s'...", fpath)
+		dest, err := read(fpath, configuration())
+		if err != nil {
+			return err
+		}
+		for _, attachment := range attachments {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			filename := filepath.Base(attachment.Fpath)
+			content, err := ioutil.ReadFile(attachment.Fpath)
+			if err != nil {
+				return err
+			}
+			relationship := attachment.Relationship
+			if filename == FacturXFilename {
+				level, err := facturXConformanceLevel(content)
+				if err != nil {
+					return err
+				}
+				relationship = facturXRelationship(level)
+				if err := addFacturXMetadata(dest, level); err != nil {
+					return err
+				}
+			}
+			logger.DebugOpf(op, "attaching '%s' to '%s' (%s)...", filename, fpath, relationship)
+			if err := attach(dest, filename, content, relationship); err != nil {
+				return err
+			}
+		}
+		return replace(dest, fpath)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+attach adds the given content to the embedded
+files and to the associated files of the
+catalog.
+*/
+func attach(ctx *model.Context, filename string, content []byte, relationship string) error {
+	const op string = "xpdf.attach"
+	resolver := func() error {
+		sd, err := ctx.NewStreamDictForBuf(content)
+		if err != nil {
+			return err
+		}
+		sd.InsertName("Type", "EmbeddedFile")
+		// PDF/A-3 requires the MIME type.
+		mimeType := mime.TypeByExtension(filepath.Ext(filename))
+		if i := strings.Index(mimeType, ";"); i > 0 {
+			mimeType = mimeType[:i]
+		}
+		if mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+		sd.InsertName("Subtype", mimeType)
+		params := types.NewDict()
+		params.InsertInt("Size", len(content))
+		params.Insert("ModDate", types.StringLiteral(types.DateString(time.Now())))
+		sd.Insert("Params", params)
+		if err := sd.Encode(); err != nil {
+			return err
+		}
+		sdRef, err := ctx.IndRefForNewObject(*sd)
+		if err != nil {
+			return err
+		}
+		fileSpec, err := ctx.NewFileSpecDict(filename, filename, "", *sdRef)
+		if err != nil {
+			return err
+		}
+		fileSpec.Delete("CI")
+		fileSpec.InsertName("AFRelationship", relationship)
+		fileSpecRef, err := ctx.IndRefForNewObject(fileSpec)
+		if err != nil {
+			return err
+		}
+		if err := ctx.LocateNameTree("EmbeddedFiles", true); err != nil {
+			return err
+		}
+		m := model.NameMap{filename: []types.Dict{fileSpec}}
+		if err := ctx.Names["EmbeddedFiles"].Add(ctx.XRefTable, filename, *fileSpecRef, m, []string{"F", "UF"}); err != nil {
+			return err
+		}
+		catalog, err := ctx.Catalog()
+		if err != nil {
+			return err
+		}
+		var af types.Array
+		if obj, found := catalog.Find("AF"); found {
+			arr, err := ctx.DereferenceArray(obj)
+			if err != nil {
+				return err
+			}
+			af = arr
+		}
+		catalog.Update("AF", append(af, *fileSpecRef))
+		return nil
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+// nolint: gochecknoglobals
+var facturXGuidelineRegexp = regexp.MustCompile(
+	`<(?:\w+:)?GuidelineSpecifiedDocumentContextParameter>\s*<(?:\w+:)?ID>\s*([^<\s]+)\s*<`,
+)
+
+/*
+facturXConformanceLevel returns the Factur-X
+conformance level of the given XML invoice,
+according to its guideline.
+
+It returns an xerror.Error with xerror.InvalidCode
+if the guideline is unknown.
+*/
+func facturXConformanceLevel(content []byte) (string, error) {
+	const op string = "xpdf.facturXConformanceLevel"
+	match := facturXGuidelineRegexp.FindSubmatch(content)
+	if match == nil {
+		return "", xerror.Invalid(
+			op,
+			fmt.Sprintf("'%s' is not a Factur-X invoice: no guideline found", FacturXFilename),
+			nil,
+		)
+	}
+	guideline := strings.ToLower(string(match[1]))
+	switch {
+	case strings.Contains(guideline, "factur-x.eu:1p0:minimum"):
+		return "MINIMUM", nil
+	case strings.Contains(guideline, "factur-x.eu:1p0:basicwl"):
+		return "BASIC WL", nil
+	case strings.Contains(guideline, "factur-x.eu:1p0:basic"):
+		return "BASIC", nil
+	case strings.Contains(guideline, "factur-x.eu:1p0:extended"):
+		return "EXTENDED", nil
+	case strings.Contains(guideline, "xrechnung"):
+		return "XRECHNUNG", nil
+	case strings.HasPrefix(guideline, "urn:cen.eu:en16931:2017"):
+		return "EN 16931", nil
+	default:
+		return "", xerror.Invalid(
+			op,
+			fmt.Sprintf("'%s' is not a Factur-X invoice: unknown guideline '%s'", FacturXFilename, match[1]),
+			nil,
+		)
+	}
+}
+
+/*
+facturXRelationship returns the AFRelationship
+of a Factur-X invoice: the MINIMUM and BASIC WL
+invoices are not complete invoices.
+*/
+func facturXRelationship(level string) string {
+	if level == "MINIMUM" || level == "BASIC WL" {
+		return DataRelationship
+	}
+	return AlternativeRelationship
+}
+
+const facturXMetadata string = `<rdf:Description rdf:about="" xmlns:fx="urn:factur-x:pdfa:CrossIndustryDocument:invoice:1p0#">
+<fx:DocumentType>INVOICE</fx:DocumentType>
+<fx:DocumentFileName>factur-x.xml</fx:DocumentFileName>
+<fx:Version>1.0</fx:Version>
+<fx:ConformanceLevel>%s</fx:ConformanceLevel>
+</rdf:Description>
+<rdf:Description rdf:about="" xmlns:pdfaExtension="http://www.aiim.org/pdfa/ns/extension/" xmlns:pdfaSchema="http://www.aiim.org/pdfa/ns/schema#" xmlns:pdfaProperty="http://www.aiim.org/pdfa/ns/property#">
+<pdfaExtension:schemas>
+<rdf:Bag>
+<rdf:li rdf:parseType="Resource">
+<pdfaSchema:schema>Factur-X PDFA Extension Schema</pdfaSchema:schema>
+<pdfaSchema:namespaceURI>urn:factur-x:pdfa:CrossIndustryDocument:invoice:1p0#</pdfaSchema:namespaceURI>
+<pdfaSchema:prefix>fx</pdfaSchema:prefix>
+<pdfaSchema:property>
+<rdf:Seq>
+<rdf:li rdf:parseType="Resource"><pdfaProperty:name>DocumentFileName</pdfaProperty:name><pdfaProperty:valueType>Text</pdfaProperty:valueType><pdfaProperty:category>external</pdfaProperty:category><pdfaProperty:description>The name of the embedded XML document</pdfaProperty:description></rdf:li>
+<rdf:li rdf:parseType="Resource"><pdfaProperty:name>DocumentType</pdfaProperty:name><pdfaProperty:valueType>Text</pdfaProperty:valueType><pdfaProperty:category>external</pdfaProperty:category><pdfaProperty:description>The type of the hybrid document in capital letters, e.g. INVOICE or ORDER</pdfaProperty:description></rdf:li>
+<rdf:li rdf:parseType="Resource"><pdfaProperty:name>Version</pdfaProperty:name><pdfaProperty:valueType>Text</pdfaProperty:valueType><pdfaProperty:category>external</pdfaProperty:category><pdfaProperty:description>The actual version of the standard applying to the embedded XML document</pdfaProperty:description></rdf:li>
+<rdf:li rdf:parseType="Resource"><pdfaProperty:name>ConformanceLevel</pdfaProperty:name><pdfaProperty:valueType>Text</pdfaProperty:valueType><pdfaProperty:category>external</pdfaProperty:category><pdfaProperty:description>The conformance level of the embedded XML document</pdfaProperty:description></rdf:li>
+</rdf:Seq>
+</pdfaSchema:property>
+</rdf:li>
+</rdf:Bag>
+</pdfaExtension:schemas>
+</rdf:Description>
+`
+
+/*
+addFacturXMetadata adds the Factur-X schema to
+the XMP metadata of a PDF/A-3 file.
+
+It returns an xerror.Error with xerror.InvalidCode
+if the PDF file is not a PDF/A-3 file.
+*/
+func addFacturXMetadata(ctx *model.Context, level string) error {
+	const op string = "xpdf.addFacturXMetadata"
+	resolver := func() error {
+		xmp, err := metadata(ctx)
+		if err != nil {
+			return err
+		}
+		part := pdfaPartRegexp.FindSubmatch(xmp)
+		if part == nil || string(part[1]) != "3" {
+			return xerror.Invalid(
+				op,
+				fmt.Sprintf("a Factur-X invoice requires a '%s' file", PDFA3b),
+				nil,
+			)
+		}
+		xmp, err = insertDescription(xmp, fmt.Sprintf(facturXMetadata, level))
+		if err != nil {
+			return err
+		}
+		return setMetadata(ctx, xmp)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+insertDescription inserts the given
+rdf:Description elements into the given
+XMP metadata.
+*/
+func insertDescription(xmp []byte, description string) ([]byte, error) {
+	const (
+		op  string = "xpdf.insertDescription"
+		end string = "</rdf:RDF>"
+	)
+	i := bytes.LastIndex(xmp, []byte(end))
+	if i < 0 {
+		return nil, xerror.New(op, fmt.Errorf("no '%s' in XMP metadata", end))
+	}
+	var result bytes.Buffer
+	result.Write(xmp[:i])
+	result.WriteString(description)
+	result.Write(xmp[i:])
+	return result.Bytes(), nil
+}
@@ -0,0 +1,92 @@
+package xpdf
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestAttach(t *testing.T) {
+	logger := test.DebugLogger()
+	fpaths := test.AttachmentFpaths(t)
+	copyPDF := func(pdfa bool) string {
+		ctx, err := read(test.MergeFpaths(t)[0], configuration())
+		require.Nil(t, err)
+		if pdfa {
+			xmp := `<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">` +
+				`<rdf:Description rdf:about="" xmlns:pdfaid="http://www.aiim.org/pdfa/ns/id/">` +
+				`<pdfaid:part>3</pdfaid:part><pdfaid:conformance>B</pdfaid:conformance>` +
+				`</rdf:Description></rdf:RDF></x:xmpmeta>`
+			err = setMetadata(ctx, []byte(xmp))
+			require.Nil(t, err)
+		}
+		dest := test.GenerateDestination()
+		err = write(ctx, dest)
+		require.Nil(t, err)
+		return dest
+	}
+	// should be OK.
+	dest := copyPDF(false)
+	err := Attach(context.Background(), logger, dest, []Attachment{{Fpath: fpaths[1], Relationship: SupplementRelationship}})
+	assert.Nil(t, err)
+	ctx, err := read(dest, configuration())
+	require.Nil(t, err)
+	attachments, err := ctx.ListAttachments()
+	require.Nil(t, err)
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "notes.txt", attachments[0].FileName)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as a Factur-X
+	// invoice requires a PDF/A-3 file.
+	dest = copyPDF(false)
+	err = Attach(context.Background(), logger, dest, []Attachment{{Fpath: fpaths[0]}})
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should be OK as the PDF file
+	// is a PDF/A-3 file.
+	dest = copyPDF(true)
+	err = Attach(context.Background(), logger, dest, []Attachment{{Fpath: fpaths[0]}, {Fpath: fpaths[1], Relationship: UnspecifiedRelationship}})
+	assert.Nil(t, err)
+	ctx, err = read(dest, configuration())
+	require.Nil(t, err)
+	attachments, err = ctx.ListAttachments()
+	require.Nil(t, err)
+	assert.Len(t, attachments, 2)
+	xmp, err := metadata(ctx)
+	require.Nil(t, err)
+	assert.Contains(t, string(xmp), "<fx:ConformanceLevel>EN 16931</fx:ConformanceLevel>")
+	err = CheckPDFA(dest, PDFA3b)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+}
+
+func TestFacturXConformanceLevel(t *testing.T) {
+	content, err := ioutil.ReadFile(test.AttachmentFpaths(t)[0])
+	require.Nil(t, err)
+	// should be OK.
+	level, err := facturXConformanceLevel(content)
+	assert.Nil(t, err)
+	assert.Equal(t, "EN 16931", level)
+	assert.Equal(t, AlternativeRelationship, facturXRelationship(level))
+	level, err = facturXConformanceLevel([]byte(
+		`<ram:GuidelineSpecifiedDocumentContextParameter><ram:ID>urn:factur-x.eu:1p0:basicwl</ram:ID></ram:GuidelineSpecifiedDocumentContextParameter>`,
+	))
+	assert.Nil(t, err)
+	assert.Equal(t, "BASIC WL", level)
+	assert.Equal(t, DataRelationship, facturXRelationship(level))
+	// should not be OK as there is
+	// no guideline.
+	_, err = facturXConformanceLevel([]byte("<invoice/>"))
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+}
@@ -0,0 +1,208 @@
+package xpdf
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+)
+
+// Bookmark is an item of the
+// outline of a PDF file.
+type Bookmark struct {
+	Title string
+	// Page starts from 1.
+	Page int
+	Kids []Bookmark
+}
+
+/*
+FileBookmarks returns one Bookmark per given
+PDF file, pointing to the first page of the
+file once merged in the given order.
+
+It returns an xerror.Error with xerror.InvalidCode
+if one of the files is corrupt or encrypted.
+*/
+func FileBookmarks(fpaths, titles []string) ([]Bookmark, error) {
+	const op string = "xpdf.FileBookmarks"
+	resolver := func() ([]Bookmark, error) {
+		if len(fpaths) != len(titles) {
+			return nil, fmt.Errorf("got %d titles for %d PDF files", len(titles), len(fpaths))
+		}
+		bookmarks := make([]Bookmark, 0, len(fpaths))
+		page := 1
+		for i, fpath := range fpaths {
+			ctx, err := read(fpath, configuration())
+			if err != nil {
+				return nil, err
+			}
+			// a file without pages has
+			// no page to point to.
+			if ctx.PageCount == 0 {
+				continue
+			}
+			bookmarks = append(bookmarks, Bookmark{Title: titles[i], Page: page})
+			page += ctx.PageCount
+		}
+		return bookmarks, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+Bookmarks returns the outline of the
+given PDF file, or nil if there is none.
+
+It returns an xerror.Error with xerror.InvalidCode
+if the file is corrupt or encrypted.
+*/
+func Bookmarks(fpath string) ([]Bookmark, error) {
+	const op string = "xpdf.Bookmarks"
+	resolver := func() ([]Bookmark, error) {
+		ctx, err := read(fpath, configuration())
+		if err != nil {
+			return nil, err
+		}
+		bms, err := pdfcpu.Bookmarks(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var convert func(bms []pdfcpu.Bookmark) []Bookmark
+		convert = func(bms []pdfcpu.Bookmark) []Bookmark {
+			var bookmarks []Bookmark
+			for _, bm := range bms {
+				bookmarks = append(bookmarks, Bookmark{
+					Title: bm.Title,
+					Page:  bm.PageFrom,
+					Kids:  convert(bm.Kids),
+				})
+			}
+			return bookmarks
+		}
+		return convert(bms), nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+SetBookmarks replaces the outline of
+the given PDF file, in place.
+
+It returns an xerror.Error with xerror.InvalidCode
+if the file is corrupt or encrypted.
+*/
+func SetBookmarks(ctx context.Context, logger xlog.Logger, fpath string, bookmarks []Bookmark) error {
+	const op string = "xpdf.SetBookmarks"
+	resolver := func() error {
+		logger.DebugOpf(op, "reading '%s'...", fpath)
+		dest, err := read(fpath, configuration())
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		catalog, err := dest.Catalog()
+		if err != nil {
+			return err
+		}
+		outlines := types.Dict(map[string]types.Object{"Type": types.Name("Outlines")})
+		ref, err := dest.IndRefForNewObject(outlines)
+		if err != nil {
+			return err
+		}
+		logger.DebugOpf(op, "adding %d bookmark(s) to '%s'...", len(bookmarks), fpath)
+		first, last, count, err := outlineItems(dest, bookmarks, *ref)
+		if err != nil {
+			return err
+		}
+		if first != nil {
+			outlines["First"] = *first
+			outlines["Last"] = *last
+			outlines["Count"] = types.Integer(count)
+		}
+		catalog.Update("Outlines", *ref)
+		return replace(dest, fpath)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+outlineItems creates the linked outline items
+of the given bookmarks. It returns the first
+and last items, and the number of items.
+
+The destinations are explicit, as named
+destinations require unique titles.
+*/
+func outlineItems(ctx *model.Context, bookmarks []Bookmark, parent types.IndirectRef) (*types.IndirectRef, *types.IndirectRef, int, error) {
+	const op string = "xpdf.outlineItems"
+	var (
+		first, previous *types.IndirectRef
+		previousItem    types.Dict
+		count           int
+	)
+	for _, bookmark := range bookmarks {
+		if bookmark.Page < 1 || bookmark.Page > ctx.PageCount {
+			return nil, nil, 0, xerror.Invalid(
+				op,
+				fmt.Sprintf("bookmark '%s' points to page %d of %d", bookmark.Title, bookmark.Page, ctx.PageCount),
+				nil,
+			)
+		}
+		_, pageRef, _, err := ctx.PageDict(bookmark.Page, false)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		title, err := types.EscapeUTF16String(bookmark.Title)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		item := types.Dict(map[string]types.Object{
+			"Title":  types.StringLiteral(*title),
+			"Parent": parent,
+			"Dest":   types.Array{*pageRef, types.Name("Fit")},
+		})
+		ref, err := ctx.IndRefForNewObject(item)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if len(bookmark.Kids) > 0 {
+			kidsFirst, kidsLast, kidsCount, err := outlineItems(ctx, bookmark.Kids, *ref)
+			if err != nil {
+				return nil, nil, 0, err
+			}
+			item["First"] = *kidsFirst
+			item["Last"] = *kidsLast
+			// a positive count means open.
+			item["Count"] = types.Integer(kidsCount)
+			count += kidsCount
+		}
+		if first == nil {
+			first = ref
+		}
+		if previous != nil {
+			item["Prev"] = *previous
+			previousItem["Next"] = *ref
+		}
+		previous, previousItem = ref, item
+		count++
+	}
+	return first, previous, count, nil
+}
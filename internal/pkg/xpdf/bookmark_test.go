@@ -0,0 +1,48 @@
+package xpdf
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestBookmarks(t *testing.T) {
+	logger := test.DebugLogger()
+	fpaths := test.MergeFpaths(t)
+	dest := test.GenerateDestination()
+	err := Merge(context.Background(), logger, fpaths, dest)
+	require.Nil(t, err)
+	// should be OK.
+	bookmarks, err := FileBookmarks(fpaths, []string{"Report", "Report"})
+	assert.Nil(t, err)
+	require.Len(t, bookmarks, 2)
+	assert.Equal(t, 1, bookmarks[0].Page)
+	assert.True(t, bookmarks[1].Page > 1)
+	bookmarks[0].Kids = []Bookmark{{Title: "Introduction", Page: 1}}
+	err = SetBookmarks(context.Background(), logger, dest, bookmarks)
+	assert.Nil(t, err)
+	result, err := Bookmarks(dest)
+	assert.Nil(t, err)
+	assert.Equal(t, bookmarks, result)
+	// should not be OK as the number of
+	// titles does not match.
+	_, err = FileBookmarks(fpaths, []string{"Report"})
+	test.AssertError(t, err)
+	// should not be OK as the page
+	// does not exist.
+	err = SetBookmarks(context.Background(), logger, dest, []Bookmark{{Title: "Appendix", Page: 1000}})
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should be OK as a PDF file
+	// may have no outline.
+	result, err = Bookmarks(fpaths[0])
+	assert.Nil(t, err)
+	assert.Empty(t, result)
+}
@@ -0,0 +1,8 @@
+/*
+Package xpdf helps manipulating PDF files
+natively thanks to the pdfcpu library.
+
+All functions return our standard xerror.Error
+in case of error.
+*/
+package xpdf
@@ -0,0 +1,95 @@
+package xpdf
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+)
+
+// Encryption gathers the passwords and
+// the permissions of an encrypted PDF file.
+type Encryption struct {
+	// UserPassword is required for
+	// opening the PDF file.
+	UserPassword string
+	// OwnerPassword is required for
+	// changing the permissions.
+	OwnerPassword string
+	AllowPrinting bool
+	AllowCopying  bool
+}
+
+/*
+String returns a representation of the
+Encryption without the passwords, so that
+they are never logged.
+*/
+func (e Encryption) String() string {
+	mask := func(password string) string {
+		if password == "" {
+			return ""
+		}
+		return "***"
+	}
+	return fmt.Sprintf(
+		"{UserPassword:%s OwnerPassword:%s AllowPrinting:%t AllowCopying:%t}",
+		mask(e.UserPassword),
+		mask(e.OwnerPassword),
+		e.AllowPrinting,
+		e.AllowCopying,
+	)
+}
+
+// permissions returns the pdfcpu
+// permissions of the Encryption.
+func (e Encryption) permissions() model.PermissionFlags {
+	permissions := model.PermissionsAll
+	if !e.AllowPrinting {
+		permissions &^= model.PermissionPrintRev2 | model.PermissionPrintRev3
+	}
+	if !e.AllowCopying {
+		permissions &^= model.PermissionExtract | model.PermissionExtractRev3
+	}
+	return permissions
+}
+
+/*
+Encrypt encrypts the given PDF file in place
+with AES-256.
+
+It returns an xerror.Error with xerror.InvalidCode
+if the file is corrupt or already encrypted.
+*/
+func Encrypt(ctx context.Context, logger xlog.Logger, fpath string, encryption Encryption) error {
+	const op string = "xpdf.Encrypt"
+	resolver := func() error {
+		conf := configuration()
+		conf.Cmd = model.ENCRYPT
+		conf.EncryptUsingAES = true
+		conf.EncryptKeyLength = 256
+		conf.UserPW = encryption.UserPassword
+		conf.OwnerPW = encryption.OwnerPassword
+		conf.Permissions = encryption.permissions()
+		logger.DebugOpf(op, "reading '%s'...", fpath)
+		dest, err := read(fpath, conf)
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := api.OptimizeContext(dest); err != nil {
+			return err
+		}
+		logger.DebugOpf(op, "encrypting '%s'...", fpath)
+		return replace(dest, fpath)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
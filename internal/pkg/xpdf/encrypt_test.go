@@ -0,0 +1,64 @@
+package xpdf
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestEncrypt(t *testing.T) {
+	logger := test.DebugLogger()
+	fpath := test.MergeFpaths(t)[0]
+	b, err := ioutil.ReadFile(fpath)
+	require.Nil(t, err)
+	// should encrypt the PDF file.
+	dest := test.GenerateDestination()
+	err = ioutil.WriteFile(dest, b, 0600)
+	require.Nil(t, err)
+	encryption := Encryption{UserPassword: "foo", OwnerPassword: "bar"}
+	err = Encrypt(context.Background(), logger, dest, encryption)
+	assert.Nil(t, err)
+	// the user password is required.
+	_, err = api.PageCountFile(dest)
+	assert.NotNil(t, err)
+	conf := model.NewAESConfiguration("foo", "", 256)
+	permissions, err := api.GetPermissionsFile(dest, conf)
+	assert.Nil(t, err)
+	require.NotNil(t, permissions)
+	flags := model.PermissionFlags(*permissions)
+	assert.Zero(t, flags&model.PermissionPrintRev3)
+	assert.Zero(t, flags&model.PermissionExtract)
+	// should not be OK as the PDF file
+	// is already encrypted.
+	err = Encrypt(context.Background(), logger, dest, encryption)
+	test.AssertError(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as context.Context
+	// has been canceled.
+	dest = test.GenerateDestination()
+	err = ioutil.WriteFile(dest, b, 0600)
+	require.Nil(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = Encrypt(ctx, logger, dest, encryption)
+	test.AssertError(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+}
+
+func TestEncryptionString(t *testing.T) {
+	encryption := Encryption{UserPassword: "foo", OwnerPassword: "bar", AllowPrinting: true}
+	result := fmt.Sprintf("%+v", struct{ Encryption Encryption }{encryption})
+	assert.NotContains(t, result, "foo")
+	assert.NotContains(t, result, "bar")
+	assert.Contains(t, result, "AllowPrinting:true")
+}
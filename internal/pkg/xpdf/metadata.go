@@ -0,0 +1,166 @@
+package xpdf
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+)
+
+// Metadata gathers the document
+// information of a PDF file.
+type Metadata struct {
+	Title    string
+	Author   string
+	Subject  string
+	Keywords string
+}
+
+// IsEmpty returns true if there
+// is no document information.
+func (m Metadata) IsEmpty() bool {
+	return m == Metadata{}
+}
+
+// WithDefaults returns the metadata with the
+// given ones in place of its empty fields.
+func (m Metadata) WithDefaults(defaults Metadata) Metadata {
+	if m.Title == "" {
+		m.Title = defaults.Title
+	}
+	if m.Author == "" {
+		m.Author = defaults.Author
+	}
+	if m.Subject == "" {
+		m.Subject = defaults.Subject
+	}
+	if m.Keywords == "" {
+		m.Keywords = defaults.Keywords
+	}
+	return m
+}
+
+// properties returns the non-empty document
+// information as pdfcpu properties.
+func (m Metadata) properties() map[string]string {
+	properties := make(map[string]string)
+	for key, value := range map[string]string{
+		"Title":    m.Title,
+		"Author":   m.Author,
+		"Subject":  m.Subject,
+		"Keywords": m.Keywords,
+	} {
+		if value != "" {
+			properties[key] = value
+		}
+	}
+	return properties
+}
+
+/*
+SetMetadata sets the non-empty document
+information of the given PDF file, in place.
+
+If the PDF file has XMP metadata (e.g. a PDF/A
+file), they are updated too, as PDF/A requires
+both to match.
+
+It returns an xerror.Error with xerror.InvalidCode
+if the file is corrupt or encrypted.
+*/
+func SetMetadata(ctx context.Context, logger xlog.Logger, fpath string, metadata Metadata) error {
+	const op string = "xpdf.SetMetadata"
+	resolver := func() error {
+		conf := configuration()
+		conf.Cmd = model.ADDPROPERTIES
+		logger.DebugOpf(op, "reading '%s'...", fpath)
+		dest, err := read(fpath, conf)
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		logger.DebugOpf(op, "setting metadata of '%s'...", fpath)
+		if err := pdfcpu.PropertiesAdd(dest, metadata.properties()); err != nil {
+			return err
+		}
+		if err := setXMPMetadata(dest, metadata); err != nil {
+			return err
+		}
+		return replace(dest, fpath)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+// nolint: gochecknoglobals
+var (
+	xmpTitleRegexp       = regexp.MustCompile(`(?s)<dc:title\s*/>|<dc:title\b.*?</dc:title>`)
+	xmpCreatorRegexp     = regexp.MustCompile(`(?s)<dc:creator\s*/>|<dc:creator\b.*?</dc:creator>`)
+	xmpDescriptionRegexp = regexp.MustCompile(`(?s)<dc:description\s*/>|<dc:description\b.*?</dc:description>`)
+	xmpKeywordsRegexp    = regexp.MustCompile(`(?s)<pdf:Keywords\s*/>|<pdf:Keywords\b.*?</pdf:Keywords>|\spdf:Keywords=("[^"]*"|'[^']*')`)
+)
+
+/*
+setXMPMetadata replaces the properties of the
+XMP metadata matching the non-empty document
+information. It does nothing if the PDF file
+has no XMP metadata.
+*/
+func setXMPMetadata(ctx *model.Context, info Metadata) error {
+	const op string = "xpdf.setXMPMetadata"
+	resolver := func() error {
+		xmp, err := metadata(ctx)
+		if err != nil {
+			return err
+		}
+		if xmp == nil {
+			return nil
+		}
+		var description bytes.Buffer
+		description.WriteString(`<rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:pdf="http://ns.adobe.com/pdf/1.3/">`)
+		if info.Title != "" {
+			xmp = xmpTitleRegexp.ReplaceAll(xmp, nil)
+			fmt.Fprintf(&description, `<dc:title><rdf:Alt><rdf:li xml:lang="x-default">%s</rdf:li></rdf:Alt></dc:title>`, escape(info.Title))
+		}
+		if info.Author != "" {
+			xmp = xmpCreatorRegexp.ReplaceAll(xmp, nil)
+			fmt.Fprintf(&description, `<dc:creator><rdf:Seq><rdf:li>%s</rdf:li></rdf:Seq></dc:creator>`, escape(info.Author))
+		}
+		if info.Subject != "" {
+			xmp = xmpDescriptionRegexp.ReplaceAll(xmp, nil)
+			fmt.Fprintf(&description, `<dc:description><rdf:Alt><rdf:li xml:lang="x-default">%s</rdf:li></rdf:Alt></dc:description>`, escape(info.Subject))
+		}
+		if info.Keywords != "" {
+			xmp = xmpKeywordsRegexp.ReplaceAll(xmp, nil)
+			fmt.Fprintf(&description, `<pdf:Keywords>%s</pdf:Keywords>`, escape(info.Keywords))
+		}
+		description.WriteString("</rdf:Description>\n")
+		xmp, err = insertDescription(xmp, description.String())
+		if err != nil {
+			return err
+		}
+		return setMetadata(ctx, xmp)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+// escape escapes the given text
+// for an XML element.
+func escape(text string) string {
+	var result bytes.Buffer
+	xml.EscapeText(&result, []byte(text)) // nolint: errcheck
+	return result.String()
+}
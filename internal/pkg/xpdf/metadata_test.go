@@ -0,0 +1,68 @@
+package xpdf
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestMetadataWithDefaults(t *testing.T) {
+	m := Metadata{Title: "Invoice", Subject: "Accounting"}
+	result := m.WithDefaults(Metadata{Title: "Page", Author: "Gutenberg"})
+	assert.Equal(t, Metadata{Title: "Invoice", Author: "Gutenberg", Subject: "Accounting"}, result)
+}
+
+func TestSetMetadata(t *testing.T) {
+	logger := test.DebugLogger()
+	ctx, err := read(test.MergeFpaths(t)[0], configuration())
+	require.Nil(t, err)
+	xmp := `<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">` +
+		`<rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:pdf="http://ns.adobe.com/pdf/1.3/" pdf:Keywords="foo">` +
+		`<dc:title><rdf:Alt><rdf:li xml:lang="x-default">Untitled</rdf:li></rdf:Alt></dc:title>` +
+		`</rdf:Description></rdf:RDF></x:xmpmeta>`
+	err = setMetadata(ctx, []byte(xmp))
+	require.Nil(t, err)
+	dest := test.GenerateDestination()
+	err = write(ctx, dest)
+	require.Nil(t, err)
+	// should be OK.
+	info := Metadata{
+		Title:    "Invoice <2020>",
+		Author:   "Gutenberg",
+		Keywords: "invoice, 2020",
+	}
+	assert.False(t, info.IsEmpty())
+	err = SetMetadata(context.Background(), logger, dest, info)
+	assert.Nil(t, err)
+	ctx, err = read(dest, configuration())
+	require.Nil(t, err)
+	assert.Equal(t, "Invoice <2020>", ctx.Title)
+	assert.Equal(t, "Gutenberg", ctx.Author)
+	assert.Equal(t, "invoice, 2020", ctx.Keywords)
+	result, err := metadata(ctx)
+	require.Nil(t, err)
+	assert.NotContains(t, string(result), "Untitled")
+	assert.NotContains(t, string(result), `pdf:Keywords="foo"`)
+	assert.Contains(t, string(result), "Invoice &lt;2020&gt;")
+	assert.Contains(t, string(result), "<rdf:li>Gutenberg</rdf:li>")
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should be OK as a PDF file may
+	// have no XMP metadata.
+	dest = test.GenerateDestination()
+	ctx, err = read(test.MergeFpaths(t)[0], configuration())
+	require.Nil(t, err)
+	err = write(ctx, dest)
+	require.Nil(t, err)
+	err = SetMetadata(context.Background(), logger, dest, Metadata{Subject: "Gotenberg"})
+	assert.Nil(t, err)
+	ctx, err = read(dest, configuration())
+	require.Nil(t, err)
+	assert.Equal(t, "Gotenberg", ctx.Subject)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,120 @@
+package xpdf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+)
+
+/*
+Overlay is a text or the first page of
+a PDF file displayed on the pages of
+another PDF file.
+*/
+type Overlay struct {
+	// Text is the text of the overlay
+	// if there is no PDF file.
+	Text string
+	// Fpath is the path of the PDF file
+	// of the overlay.
+	Fpath string
+	// OnTop is true for a stamp (foreground)
+	// and false for a watermark (background).
+	OnTop bool
+	// Opacity goes from 0.0 to 1.0.
+	Opacity float64
+	// Rotation goes from -180.0 to 180.0 degrees.
+	Rotation float64
+	// PageRanges are the pages which display
+	// the overlay. All pages if empty.
+	PageRanges []PageRange
+}
+
+/*
+AddOverlay displays the given Overlay on the
+pages of the given PDF file, in place.
+
+It returns an xerror.Error with xerror.InvalidCode
+if one of the files is corrupt or encrypted.
+*/
+func AddOverlay(ctx context.Context, logger xlog.Logger, fpath string, overlay Overlay) error {
+	const op string = "xpdf.AddOverlay"
+	resolver := func() error {
+		conf := configuration()
+		conf.Cmd = model.ADDWATERMARKS
+		conf.OptimizeDuplicateContentStreams = false
+		desc := fmt.Sprintf("opacity:%g, rotation:%g", overlay.Opacity, overlay.Rotation)
+		var (
+			wm  *model.Watermark
+			err error
+		)
+		if overlay.Fpath != "" {
+			// pdfcpu does not validate the PDF file
+			// of the overlay before using it.
+			if _, err := read(overlay.Fpath, conf); err != nil {
+				return err
+			}
+			// ":1" means the first page for all pages.
+			wm, err = api.PDFWatermark(fmt.Sprintf("%s:1", overlay.Fpath), desc, overlay.OnTop, false, types.POINTS)
+		} else {
+			wm, err = api.TextWatermark(overlay.Text, desc, overlay.OnTop, false, types.POINTS)
+		}
+		if err != nil {
+			return err
+		}
+		logger.DebugOpf(op, "reading '%s'...", fpath)
+		dest, err := read(fpath, conf)
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var selectedPages []string
+		for _, r := range overlay.PageRanges {
+			selectedPages = append(selectedPages, r.String())
+		}
+		pages, err := api.PagesForPageSelection(dest.PageCount, selectedPages, true, false)
+		if err != nil {
+			return err
+		}
+		logger.DebugOpf(op, "adding overlay to '%s'...", fpath)
+		if err := pdfcpu.AddWatermarks(dest, pages, wm); err != nil {
+			return err
+		}
+		return replace(dest, fpath)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+replace writes the PDF file to a temporary
+file before replacing the given file, as
+pdfcpu may still read from it while writing.
+*/
+func replace(ctx *model.Context, fpath string) error {
+	const op string = "xpdf.replace"
+	resolver := func() error {
+		tmp := fmt.Sprintf("%s/.%s.tmp", filepath.Dir(fpath), filepath.Base(fpath))
+		if err := write(ctx, tmp); err != nil {
+			os.Remove(tmp) // nolint: errcheck
+			return err
+		}
+		return os.Rename(tmp, fpath)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package xpdf
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xrand"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestAddOverlay(t *testing.T) {
+	logger := test.DebugLogger()
+	fpaths := test.MergeFpaths(t)
+	copyFile := func() string {
+		b, err := ioutil.ReadFile(fpaths[0])
+		require.Nil(t, err)
+		dest := test.GenerateDestination()
+		err = ioutil.WriteFile(dest, b, 0600)
+		require.Nil(t, err)
+		return dest
+	}
+	// should add a text watermark.
+	dest := copyFile()
+	err := AddOverlay(context.Background(), logger, dest, Overlay{Text: "DRAFT", Opacity: 0.5, Rotation: 45})
+	assert.Nil(t, err)
+	ok, err := api.HasWatermarksFile(dest, nil)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should add a PDF stamp on the
+	// first page.
+	dest = copyFile()
+	err = AddOverlay(context.Background(), logger, dest, Overlay{
+		Fpath:      fpaths[1],
+		OnTop:      true,
+		Opacity:    1,
+		PageRanges: []PageRange{{From: 1, To: 1}},
+	})
+	assert.Nil(t, err)
+	ok, err = api.HasWatermarksFile(dest, nil)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as the PDF file
+	// of the overlay is corrupt.
+	dest = copyFile()
+	corrupt := fmt.Sprintf("/tmp/%s.pdf", xrand.Get())
+	err = ioutil.WriteFile(corrupt, []byte("foo"), 0600)
+	require.Nil(t, err)
+	err = AddOverlay(context.Background(), logger, dest, Overlay{Fpath: corrupt, Opacity: 1})
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	err = os.RemoveAll(corrupt)
+	assert.Nil(t, err)
+	// should not be OK as context.Context
+	// has been canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = AddOverlay(ctx, logger, dest, Overlay{Text: "DRAFT", Opacity: 1})
+	test.AssertError(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,148 @@
+package xpdf
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+)
+
+const (
+	// PDFA1b is the PDF/A-1b format.
+	PDFA1b string = "PDF/A-1b"
+	// PDFA2b is the PDF/A-2b format.
+	PDFA2b string = "PDF/A-2b"
+	// PDFA3b is the PDF/A-3b format.
+	PDFA3b string = "PDF/A-3b"
+)
+
+/*
+PDFFormats returns a slice
+containing all available PDF
+formats.
+*/
+func PDFFormats() []string {
+	return []string{
+		PDFA1b,
+		PDFA2b,
+		PDFA3b,
+	}
+}
+
+// nolint: gochecknoglobals
+var (
+	pdfaPartRegexp        = regexp.MustCompile(`pdfaid:part(?:>|=["'])\s*(\d)`)
+	pdfaConformanceRegexp = regexp.MustCompile(`pdfaid:conformance(?:>|=["'])\s*([ABUabu])`)
+)
+
+/*
+CheckPDFA checks that the XMP metadata of
+the given PDF file declare the given PDF/A
+format.
+
+It returns an xerror.Error with xerror.InvalidCode
+if it does not.
+*/
+func CheckPDFA(fpath, format string) error {
+	const op string = "xpdf.CheckPDFA"
+	resolver := func() error {
+		ctx, err := read(fpath, configuration())
+		if err != nil {
+			return err
+		}
+		xmp, err := metadata(ctx)
+		if err != nil {
+			return err
+		}
+		declared := ""
+		part := pdfaPartRegexp.FindSubmatch(xmp)
+		conformance := pdfaConformanceRegexp.FindSubmatch(xmp)
+		if part != nil && conformance != nil {
+			declared = fmt.Sprintf("PDF/A-%s%s", part[1], strings.ToLower(string(conformance[1])))
+		}
+		if declared != format {
+			return xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' cannot be converted to '%s'", filepath.Base(fpath), format),
+				nil,
+			)
+		}
+		return nil
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+metadata returns the decoded XMP metadata
+of the catalog, or nil if there are none.
+*/
+func metadata(ctx *model.Context) ([]byte, error) {
+	const op string = "xpdf.metadata"
+	resolver := func() ([]byte, error) {
+		catalog, err := ctx.Catalog()
+		if err != nil {
+			return nil, err
+		}
+		obj, found := catalog.Find("Metadata")
+		if !found || obj == nil {
+			return nil, nil
+		}
+		sd, _, err := ctx.DereferenceStreamDict(obj)
+		if err != nil {
+			return nil, err
+		}
+		if sd == nil {
+			return nil, nil
+		}
+		if err := sd.Decode(); err != nil {
+			return nil, err
+		}
+		return sd.Content, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+setMetadata replaces the XMP metadata of the
+catalog. The stream is not filtered, as
+required by PDF/A.
+*/
+func setMetadata(ctx *model.Context, xmp []byte) error {
+	const op string = "xpdf.setMetadata"
+	resolver := func() error {
+		catalog, err := ctx.Catalog()
+		if err != nil {
+			return err
+		}
+		sd := types.StreamDict{
+			Dict:    types.NewDict(),
+			Content: xmp,
+		}
+		sd.InsertName("Type", "Metadata")
+		sd.InsertName("Subtype", "XML")
+		if err := sd.Encode(); err != nil {
+			return err
+		}
+		ref, err := ctx.IndRefForNewObject(sd)
+		if err != nil {
+			return err
+		}
+		catalog.Update("Metadata", *ref)
+		return nil
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package xpdf
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestCheckPDFA(t *testing.T) {
+	fpath := test.MergeFpaths(t)[0]
+	// should not be OK as the PDF file
+	// is not a PDF/A file.
+	err := CheckPDFA(fpath, PDFA2b)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// should be OK as the XMP metadata
+	// declare the PDF/A format.
+	ctx, err := read(fpath, configuration())
+	require.Nil(t, err)
+	xmp := `<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">` +
+		`<rdf:Description rdf:about="" xmlns:pdfaid="http://www.aiim.org/pdfa/ns/id/">` +
+		`<pdfaid:part>2</pdfaid:part><pdfaid:conformance>B</pdfaid:conformance>` +
+		`</rdf:Description></rdf:RDF></x:xmpmeta>`
+	err = setMetadata(ctx, []byte(xmp))
+	require.Nil(t, err)
+	dest := test.GenerateDestination()
+	err = write(ctx, dest)
+	require.Nil(t, err)
+	err = CheckPDFA(dest, PDFA2b)
+	assert.Nil(t, err)
+	// should not be OK as the PDF/A
+	// format is not the expected one.
+	err = CheckPDFA(dest, PDFA1b)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+}
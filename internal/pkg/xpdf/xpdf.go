@@ -0,0 +1,299 @@
+package xpdf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+)
+
+/*
+Merge merges the given PDF files into
+destination, in the given order.
+
+It returns an xerror.Error with xerror.InvalidCode
+if one of the files is corrupt or encrypted.
+*/
+func Merge(ctx context.Context, logger xlog.Logger, fpaths []string, destination string) error {
+	const op string = "xpdf.Merge"
+	resolver := func() error {
+		if len(fpaths) == 0 {
+			return xerror.Invalid(op, "no PDF file to merge", nil)
+		}
+		conf := configuration()
+		conf.Cmd = model.MERGECREATE
+		conf.CreateBookmarks = false
+		logger.DebugOpf(op, "reading '%s'...", fpaths[0])
+		dest, err := read(fpaths[0], conf)
+		if err != nil {
+			return err
+		}
+		if dest.Version() < model.V20 {
+			dest.EnsureVersionForWriting()
+		}
+		for _, fpath := range fpaths[1:] {
+			// stop as soon as possible if the
+			// conversion has been canceled.
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			logger.DebugOpf(op, "reading '%s'...", fpath)
+			source, err := read(fpath, conf)
+			if err != nil {
+				return err
+			}
+			if dest.Version() < model.V20 && source.Version() == model.V20 {
+				return xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' is a PDF 2.0 file which cannot be merged with older PDF files", filepath.Base(fpath)),
+					nil,
+				)
+			}
+			if err := pdfcpu.MergeXRefTables(filepath.Base(fpath), source, dest, false, false); err != nil {
+				return err
+			}
+		}
+		if err := api.OptimizeContext(dest); err != nil {
+			return err
+		}
+		logger.DebugOpf(op, "writing '%s'...", destination)
+		return write(dest, destination)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+// PageRange is a range of pages, starting from 1.
+type PageRange struct {
+	From int
+	// To is 0 if the range goes
+	// until the last page.
+	To int
+}
+
+func (r PageRange) String() string {
+	switch {
+	case r.To == 0:
+		return fmt.Sprintf("%d-", r.From)
+	case r.From == r.To:
+		return fmt.Sprintf("%d", r.From)
+	default:
+		return fmt.Sprintf("%d-%d", r.From, r.To)
+	}
+}
+
+/*
+ParsePageRanges parses page ranges like
+"1-3,5,7-", where "7-" means from page
+7 until the last page.
+
+It returns an xerror.Error with xerror.InvalidCode
+if the page ranges are not valid.
+*/
+func ParsePageRanges(value string) ([]PageRange, error) {
+	const op string = "xpdf.ParsePageRanges"
+	invalid := func(err error) error {
+		return xerror.Invalid(
+			op,
+			fmt.Sprintf("'%s' is not a valid page ranges, e.g. '1-3,5,7-'", value),
+			err,
+		)
+	}
+	if strings.TrimSpace(value) == "" {
+		return nil, invalid(nil)
+	}
+	page := func(s string) (int, error) {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return 0, err
+		}
+		if n < 1 {
+			return 0, fmt.Errorf("page '%d' is not superior to 0", n)
+		}
+		return n, nil
+	}
+	var ranges []PageRange
+	for _, item := range strings.Split(value, ",") {
+		bounds := strings.SplitN(item, "-", 2)
+		from, err := page(bounds[0])
+		if err != nil {
+			return nil, invalid(err)
+		}
+		if len(bounds) == 1 {
+			ranges = append(ranges, PageRange{From: from, To: from})
+			continue
+		}
+		if strings.TrimSpace(bounds[1]) == "" {
+			ranges = append(ranges, PageRange{From: from})
+			continue
+		}
+		to, err := page(bounds[1])
+		if err != nil {
+			return nil, invalid(err)
+		}
+		if to < from {
+			return nil, invalid(fmt.Errorf("range '%s' ends before it starts", strings.TrimSpace(item)))
+		}
+		ranges = append(ranges, PageRange{From: from, To: to})
+	}
+	return ranges, nil
+}
+
+/*
+Split writes one PDF file per page range
+of the given PDF file into dirPath. If no
+page ranges, it writes one PDF file per page.
+
+It returns the paths of the resulting PDF files,
+in the order of the page ranges.
+
+It returns an xerror.Error with xerror.InvalidCode
+if the file is corrupt or encrypted, or if a
+page range exceeds its number of pages.
+*/
+func Split(ctx context.Context, logger xlog.Logger, fpath string, ranges []PageRange, dirPath string) ([]string, error) {
+	const op string = "xpdf.Split"
+	resolver := func() ([]string, error) {
+		logger.DebugOpf(op, "reading '%s'...", fpath)
+		source, err := read(fpath, configuration())
+		if err != nil {
+			return nil, err
+		}
+		filename := filepath.Base(fpath)
+		if len(ranges) == 0 {
+			for n := 1; n <= source.PageCount; n++ {
+				ranges = append(ranges, PageRange{From: n, To: n})
+			}
+		}
+		basename := strings.TrimSuffix(filename, filepath.Ext(filename))
+		var fpaths []string
+		for _, r := range ranges {
+			// stop as soon as possible if the
+			// conversion has been canceled.
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			to := r.To
+			if to == 0 {
+				to = source.PageCount
+			}
+			if r.From > source.PageCount || to > source.PageCount {
+				return nil, xerror.Invalid(
+					op,
+					fmt.Sprintf("page range '%s' exceeds the %d page(s) of '%s'", r, source.PageCount, filename),
+					nil,
+				)
+			}
+			var pages []int
+			for n := r.From; n <= to; n++ {
+				pages = append(pages, n)
+			}
+			logger.DebugOpf(op, "extracting pages '%s' from '%s'...", r, filename)
+			dest, err := pdfcpu.ExtractPages(source, pages, false)
+			if err != nil {
+				return nil, err
+			}
+			destination := fmt.Sprintf("%s/%s_%s.pdf", dirPath, basename, PageRange{From: r.From, To: to})
+			if err := write(dest, destination); err != nil {
+				return nil, err
+			}
+			fpaths = append(fpaths, destination)
+		}
+		return fpaths, nil
+	}
+	fpaths, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return fpaths, nil
+}
+
+// configuration returns a pdfcpu configuration
+// which does not rely on a configuration directory.
+func configuration() *model.Configuration {
+	api.DisableConfigDir()
+	conf := model.NewDefaultConfiguration()
+	// we accept files which are not strictly
+	// compliant, as pdftk does.
+	conf.ValidationMode = model.ValidationRelaxed
+	return conf
+}
+
+/*
+read reads and validates a PDF file.
+
+It returns an xerror.Error with xerror.InvalidCode
+if the file is corrupt or encrypted.
+*/
+func read(fpath string, conf *model.Configuration) (result *model.Context, err error) {
+	const op string = "xpdf.read"
+	filename := filepath.Base(fpath)
+	invalid := func(err error) error {
+		if isEncryptionError(err) {
+			return xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' is encrypted: remove its password before sending it", filename),
+				err,
+			)
+		}
+		return xerror.Invalid(
+			op,
+			fmt.Sprintf("'%s' is not a valid PDF file", filename),
+			err,
+		)
+	}
+	// pdfcpu may panic on some malformed files.
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = invalid(fmt.Errorf("%v", r))
+		}
+	}()
+	f, err := os.Open(fpath)
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	defer f.Close() // nolint: errcheck
+	result, err = api.ReadAndValidate(f, conf)
+	if err != nil {
+		return nil, invalid(err)
+	}
+	return result, nil
+}
+
+func isEncryptionError(err error) bool {
+	if errors.Is(err, pdfcpu.ErrWrongPassword) || errors.Is(err, pdfcpu.ErrUnknownEncryption) {
+		return true
+	}
+	return strings.Contains(err.Error(), "unsupported encryption")
+}
+
+func write(ctx *model.Context, destination string) error {
+	const op string = "xpdf.write"
+	resolver := func() error {
+		f, err := os.OpenFile(destination, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		if err := api.WriteContext(ctx, f); err != nil {
+			f.Close() // nolint: errcheck
+			return err
+		}
+		return f.Close()
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package xpdf
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xrand"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestMerge(t *testing.T) {
+	logger := test.DebugLogger()
+	fpaths := test.MergeFpaths(t)
+	// should merge the PDF files.
+	dest := test.GenerateDestination()
+	err := Merge(context.Background(), logger, fpaths, dest)
+	assert.Nil(t, err)
+	expected := 0
+	for _, fpath := range fpaths {
+		count, err := api.PageCountFile(fpath)
+		require.Nil(t, err)
+		expected += count
+	}
+	result, err := api.PageCountFile(dest)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as there
+	// is no PDF file.
+	dest = test.GenerateDestination()
+	err = Merge(context.Background(), logger, nil, dest)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// should not be OK as a file
+	// is corrupt.
+	corrupt := fmt.Sprintf("/tmp/%s.pdf", xrand.Get())
+	err = ioutil.WriteFile(corrupt, []byte("foo"), 0600)
+	require.Nil(t, err)
+	err = Merge(context.Background(), logger, append(fpaths, corrupt), dest)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	assert.Contains(t, xerror.Message(err), "is not a valid PDF file")
+	err = os.RemoveAll(corrupt)
+	assert.Nil(t, err)
+	// should not be OK as a file
+	// is encrypted.
+	encrypted := test.GenerateDestination()
+	err = api.EncryptFile(fpaths[0], encrypted, model.NewAESConfiguration("foo", "bar", 256))
+	require.Nil(t, err)
+	err = Merge(context.Background(), logger, append(fpaths, encrypted), dest)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	assert.Contains(t, xerror.Message(err), "is encrypted")
+	err = os.RemoveAll(encrypted)
+	assert.Nil(t, err)
+	// should not be OK as context.Context
+	// has been canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = Merge(ctx, logger, fpaths, dest)
+	test.AssertError(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+}
+
+func TestParsePageRanges(t *testing.T) {
+	// should parse the page ranges.
+	ranges, err := ParsePageRanges("1-3, 5,7-")
+	assert.Nil(t, err)
+	assert.Equal(t, []PageRange{{From: 1, To: 3}, {From: 5, To: 5}, {From: 7}}, ranges)
+	// should not be OK as the page
+	// ranges are not valid.
+	for _, value := range []string{"", "foo", "0", "-3", "3-1", "1-foo", "1,,2"} {
+		_, err = ParsePageRanges(value)
+		test.AssertError(t, err)
+		assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	}
+}
+
+func TestSplit(t *testing.T) {
+	logger := test.DebugLogger()
+	fpath := test.MergeFpaths(t)[0]
+	count, err := api.PageCountFile(fpath)
+	require.Nil(t, err)
+	dirPath, err := ioutil.TempDir("", "split")
+	require.Nil(t, err)
+	defer os.RemoveAll(dirPath) // nolint: errcheck
+	// should write one file per page.
+	fpaths, err := Split(context.Background(), logger, fpath, nil, dirPath)
+	assert.Nil(t, err)
+	assert.Len(t, fpaths, count)
+	for _, result := range fpaths {
+		n, err := api.PageCountFile(result)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, n)
+	}
+	// should write one file per page range.
+	fpaths, err = Split(context.Background(), logger, fpath, []PageRange{{From: 1, To: 1}, {From: 1}}, dirPath)
+	assert.Nil(t, err)
+	assert.Len(t, fpaths, 2)
+	n, err := api.PageCountFile(fpaths[1])
+	assert.Nil(t, err)
+	assert.Equal(t, count, n)
+	// should not be OK as the page range
+	// exceeds the number of pages.
+	_, err = Split(context.Background(), logger, fpath, []PageRange{{From: count + 1}}, dirPath)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// should not be OK as context.Context
+	// has been canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Split(ctx, logger, fpath, nil, dirPath)
+	test.AssertError(t, err)
+}
@@ -14,7 +14,7 @@ func main() {
 		systemLogger.FatalOp(op, err)
 	}
 	// start Google Chrome headless.
-	if err := chrome.Start(systemLogger, config.GoogleChromeIgnoreCertificateErrors()); err != nil {
+	if err := chrome.Start(systemLogger, chrome.DefaultOptions(config)); err != nil {
 		systemLogger.FatalOp(op, err)
 	}
 }
@@ -21,6 +21,16 @@ func MergeMultipartForm(t *testing.T, formValues map[string]string) (*bytes.Buff
 	return multipartForm(t, "pdf", formValues, fpaths)
 }
 
+/*
+SplitMultipartForm returns the body
+for a multipart/form-data request with
+one file from "testdata/pdf" folder.
+*/
+func SplitMultipartForm(t *testing.T, formValues map[string]string) (*bytes.Buffer, string) {
+	fpaths := MergeFpaths(t)[:1]
+	return multipartForm(t, "pdf", formValues, fpaths)
+}
+
 /*
 HTMLMultipartForm returns the body
 for a multipart/form-data request with all
@@ -78,7 +88,9 @@ func multipartForm(
 		_, err = io.Copy(part, file)
 		require.Nil(t, err)
 	}
-	if kind == "url" {
+	// the given form values may
+	// override the remote URL.
+	if _, ok := formValues["remoteURL"]; kind == "url" && !ok {
 		err := writer.WriteField("remoteURL", "https://google.com")
 		require.Nil(t, err)
 	}
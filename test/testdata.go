@@ -79,6 +79,15 @@ func OfficeFpaths(t *testing.T) []string {
 	}
 }
 
+// AttachmentFpaths return the paths of all
+// files under "testdata/attachment" folder.
+func AttachmentFpaths(t *testing.T) []string {
+	return []string{
+		fpath(t, "attachment", "factur-x.xml"),
+		fpath(t, "attachment", "notes.txt"),
+	}
+}
+
 func fpath(t *testing.T, kind, filename string) string {
 	require.NotEmpty(t, kind)
 	require.NotEmpty(t, filename)